@@ -3,7 +3,10 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/kalli/openapi-http/internal/generator"
 	"github.com/kalli/openapi-http/internal/parser"
 	flag "github.com/spf13/pflag"
@@ -15,20 +18,35 @@ func main() {
 	var tag string
 	var outputFile string
 	var all bool
+	var variant string
+	var maxDepth int
+	var formats []string
+	var realistic bool
+	var seed int64
+	var body string
+	var arrayItems int
+	var contentType string
+	var eachContentType bool
+	var example string
+	var externalValues string
+	var fixturesDir string
+	var createFixtures bool
 	
 	// Set custom usage function
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "openapi-http - Generate HTTP requests from OpenAPI specs\n\n")
 		fmt.Fprintf(os.Stderr, "Usage:\n")
 		fmt.Fprintf(os.Stderr, "  openapi-http [flags] <spec-file>\n")
-		fmt.Fprintf(os.Stderr, "  openapi-http <spec-file> [operation-id] [path]\n\n")
+		fmt.Fprintf(os.Stderr, "  openapi-http <spec-file> [operation-id] [path]\n")
+		fmt.Fprintf(os.Stderr, "  openapi-http lint-examples [flags] <spec-file>\n\n")
 		fmt.Fprintf(os.Stderr, "Examples:\n")
 		fmt.Fprintf(os.Stderr, "  openapi-http spec.yaml                    # list all operations\n")
 		fmt.Fprintf(os.Stderr, "  openapi-http -i getPet spec.yaml          # generate request for operation\n")
 		fmt.Fprintf(os.Stderr, "  openapi-http -p /pet spec.yaml            # generate requests for path\n")
 		fmt.Fprintf(os.Stderr, "  openapi-http -t pet spec.yaml             # generate requests for tag\n")
 		fmt.Fprintf(os.Stderr, "  openapi-http -a spec.yaml                 # generate all requests\n")
-		fmt.Fprintf(os.Stderr, "  openapi-http spec.yaml getPet             # positional arguments\n\n")
+		fmt.Fprintf(os.Stderr, "  openapi-http spec.yaml getPet             # positional arguments\n")
+		fmt.Fprintf(os.Stderr, "  openapi-http lint-examples spec.yaml      # check examples against their schemas\n\n")
 		fmt.Fprintf(os.Stderr, "Flags:\n")
 		flag.PrintDefaults()
 	}
@@ -38,6 +56,19 @@ func main() {
 	flag.StringVarP(&tag, "tag", "t", "", "tag to filter operations by (e.g. pet)")
 	flag.StringVarP(&outputFile, "output", "o", "", "output file (default: stdout)")
 	flag.BoolVarP(&all, "all", "a", false, "generate requests for all operations")
+	flag.StringVar(&variant, "variant", "", "oneOf/anyOf branch to use in examples, by schema name or discriminator value")
+	flag.IntVar(&maxDepth, "max-depth", generator.DefaultMaxDepth, "how deeply nested schemas are generated in examples")
+	flag.StringArrayVar(&formats, "format", nil, "example value for a string format, as format=value (repeatable)")
+	flag.BoolVar(&realistic, "realistic", false, "use realistic example values based on property names")
+	flag.Int64Var(&seed, "seed", 0, "pick random example values, reproducible for the same seed")
+	flag.StringVar(&body, "body", string(generator.BodyFull), "request body to generate: full, required (only required properties) or none (a placeholder)")
+	flag.IntVar(&arrayItems, "array-items", 1, "number of items to generate for arrays and maps, random when seeded")
+	flag.StringVar(&contentType, "content-type", "", "media type to send request bodies as when offered, can be a pattern like */*+xml (default: application/json)")
+	flag.BoolVar(&eachContentType, "each-content-type", false, "generate a request for each media type of the request body, named like addPet_xml")
+	flag.StringVar(&example, "example", "", "named example to use for the request body and parameters (default: a request per named example)")
+	flag.StringVar(&externalValues, "external-values", string(generator.ExternalInline), "how externalValue examples are included in request bodies: inline (their content) or file (a reference to the file)")
+	flag.StringVar(&fixturesDir, "fixtures-dir", generator.DefaultFixturesDir, "directory, relative to the output file, that file uploads are read from")
+	flag.BoolVar(&createFixtures, "create-fixtures", false, "create empty placeholder files for the file uploads in the fixtures directory")
 	flag.Parse()
 	
 	
@@ -45,7 +76,13 @@ func main() {
 	// Handle positional arguments
 	args := flag.Args()
 
-	if flag.NArg() < 1 {
+	// lint-examples validates examples instead of generating requests
+	lint := len(args) > 0 && args[0] == "lint-examples"
+	if lint {
+		args = args[1:]
+	}
+
+	if len(args) < 1 {
 		fmt.Fprintf(os.Stderr, "usage: openapi-http [flags] <spec-file>\n")
 		fmt.Fprintf(os.Stderr, "  flags must come before spec-file\n")
 		fmt.Fprintf(os.Stderr, "\nflags:\n")
@@ -53,8 +90,13 @@ func main() {
 		os.Exit(0)
 	}
 
-	specPath := flag.Arg(0)
-	spec, err := parser.LoadSpec(specPath)
+	specPath := args[0]
+	var validationOpts []openapi3.ValidationOption
+	if lint {
+		// invalid examples are reported one by one rather than failing the load
+		validationOpts = append(validationOpts, openapi3.DisableExamplesValidation())
+	}
+	spec, err := parser.LoadSpec(specPath, validationOpts...)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error loading spec: %v\n", err)
 		os.Exit(1)
@@ -66,47 +108,20 @@ func main() {
 	}
 
 	// if --all flag is set, generate all requests
+	var ops []parser.Operation
 	if all {
-		ops := parser.FindOperations(spec, "", "", "")
-		if len(ops) == 0 {
-			fmt.Fprintf(os.Stderr, "no operations found\n")
-			os.Exit(1)
-		}
-
-		var output *os.File
-		if outputFile != "" {
-			output, err = os.Create(outputFile)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "error creating output file: %v\n", err)
-				os.Exit(1)
-			}
-			defer output.Close()
-		} else {
-			output = os.Stdout
-		}
-
-		gen := generator.NewGenerator(spec)
-		for i, op := range ops {
-			if i > 0 {
-				fmt.Fprintln(output, "")
-			}
-			req, err := gen.BuildHTTPRequest(op)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "error generating request: %v\n", err)
-				continue
-			}
-			fmt.Fprint(output, req)
+		ops = parser.FindOperations(spec, "", "", "")
+	} else if lint {
+		ops = parser.FindOperations(spec, operationID, path, tag)
+	} else {
+		// no filters → just list operations
+		if operationID == "" && path == "" && tag == "" {
+			parser.ListOperations(spec)
+			return
 		}
-		return
+		ops = parser.FindOperations(spec, operationID, path, tag)
 	}
 
-	// no filters → just list operations
-	if operationID == "" && path == "" && tag == "" {
-		parser.ListOperations(spec)
-		return
-	}
-
-	ops := parser.FindOperations(spec, operationID, path, tag)
 	if len(ops) == 0 {
 		fmt.Fprintf(os.Stderr, "no operations found\n")
 		os.Exit(1)
@@ -125,6 +140,57 @@ func main() {
 	}
 
 	gen := generator.NewGenerator(spec)
+	gen.Variant = variant
+	gen.MaxDepth = maxDepth
+	gen.Realistic = realistic
+	gen.ContentType = contentType
+	gen.EachContentType = eachContentType
+	gen.Example = example
+	gen.FixturesDir = fixturesDir
+	gen.SpecLocation = specPath
+	if outputFile != "" {
+		gen.OutputDir = filepath.Dir(outputFile)
+	}
+	if flag.CommandLine.Changed("array-items") {
+		gen.ArrayItems = arrayItems
+	}
+	switch generator.BodyMode(body) {
+	case generator.BodyFull, generator.BodyRequired, generator.BodyNone:
+		gen.Body = generator.BodyMode(body)
+	default:
+		fmt.Fprintf(os.Stderr, "invalid body %q, expected full, required or none\n", body)
+		os.Exit(1)
+	}
+	switch generator.ExternalValueMode(externalValues) {
+	case generator.ExternalInline, generator.ExternalFile:
+		gen.ExternalValues = generator.ExternalValueMode(externalValues)
+	default:
+		fmt.Fprintf(os.Stderr, "invalid external-values %q, expected inline or file\n", externalValues)
+		os.Exit(1)
+	}
+	if flag.CommandLine.Changed("seed") {
+		gen.SetSeed(seed)
+	}
+	for _, f := range formats {
+		format, example, ok := strings.Cut(f, "=")
+		if !ok {
+			fmt.Fprintf(os.Stderr, "invalid format %q, expected format=value\n", f)
+			os.Exit(1)
+		}
+		gen.RegisterFormat(format, example)
+	}
+	if lint {
+		issues := gen.LintExamples(ops)
+		for _, issue := range issues {
+			fmt.Fprintln(output, issue)
+		}
+		if len(issues) > 0 {
+			fmt.Fprintf(os.Stderr, "%d invalid examples\n", len(issues))
+			os.Exit(1)
+		}
+		return
+	}
+
 	for i, op := range ops {
 		if i > 0 {
 			fmt.Fprintln(output, "")
@@ -136,6 +202,22 @@ func main() {
 		}
 		fmt.Fprint(output, req)
 	}
+
+	if createFixtures {
+		// fixtures are referenced relative to the .http file
+		base := "."
+		if outputFile != "" {
+			base = filepath.Dir(outputFile)
+		}
+		created, err := gen.CreateFixtures(base)
+		for _, file := range created {
+			fmt.Fprintf(os.Stderr, "created %s\n", file)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error creating fixtures: %v\n", err)
+			os.Exit(1)
+		}
+	}
 }
 
 func helpText(){
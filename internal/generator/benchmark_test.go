@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/kalli/openapi-http/internal/parser"
+)
+
+// syntheticSpec builds a spec with four operations on each of n paths, all sharing the
+// same component schemas, like large real world specs do
+func syntheticSpec(n int) (*openapi3.T, []parser.Operation) {
+	str := func() *openapi3.SchemaRef {
+		return &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	}
+
+	address := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Address",
+		Value: &openapi3.Schema{
+			Type:     &openapi3.Types{"object"},
+			Required: []string{"street", "city"},
+			Properties: openapi3.Schemas{
+				"street":     str(),
+				"city":       str(),
+				"postalCode": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Pattern: `^[0-9]{4} ?[A-Z]{2}$`}},
+				"country":    &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Enum: []any{"NL", "BE", "DE"}}},
+			},
+		},
+	}
+
+	customer := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Customer",
+		Value: &openapi3.Schema{
+			Type:     &openapi3.Types{"object"},
+			Required: []string{"id", "email"},
+			Properties: openapi3.Schemas{
+				"id":        &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "uuid", ReadOnly: true}},
+				"email":     &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "email"}},
+				"createdAt": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "date-time", ReadOnly: true}},
+				"address":   address,
+				"metadata": &openapi3.SchemaRef{Value: &openapi3.Schema{
+					Type:                 &openapi3.Types{"object"},
+					AdditionalProperties: openapi3.AdditionalProperties{Schema: str()},
+				}},
+			},
+		},
+	}
+
+	line := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/OrderLine",
+		Value: &openapi3.Schema{
+			Type: &openapi3.Types{"object"},
+			Properties: openapi3.Schemas{
+				"sku":      &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Pattern: `^[A-Z]{3}-[0-9]{6}$`}},
+				"quantity": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Min: openapi3.Ptr(1.0)}},
+				"price":    &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"number"}, MultipleOf: openapi3.Ptr(0.01)}},
+			},
+		},
+	}
+
+	order := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Order",
+		Value: &openapi3.Schema{
+			Type:     &openapi3.Types{"object"},
+			Required: []string{"customer", "lines"},
+			Properties: openapi3.Schemas{
+				"id":       &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Format: "int64", ReadOnly: true}},
+				"customer": customer,
+				"lines": &openapi3.SchemaRef{Value: &openapi3.Schema{
+					Type:     &openapi3.Types{"array"},
+					Items:    line,
+					MinItems: 2,
+				}},
+				"shipping": address,
+				"status":   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Enum: []any{"open", "paid", "shipped"}}},
+			},
+		},
+	}
+
+	spec := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Servers: openapi3.Servers{{URL: "https://api.example.com"}},
+		Paths:   openapi3.NewPaths(),
+	}
+
+	idParam := &openapi3.ParameterRef{Value: &openapi3.Parameter{
+		Name:     "id",
+		In:       "path",
+		Required: true,
+		Schema:   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Format: "int64"}},
+	}}
+	content := openapi3.NewContentWithJSONSchemaRef(order)
+
+	for i := range n {
+		responses := openapi3.NewResponses()
+		responses.Set("200", &openapi3.ResponseRef{Value: &openapi3.Response{Content: content}})
+
+		body := &openapi3.RequestBodyRef{Value: &openapi3.RequestBody{Content: content}}
+		pathItem := &openapi3.PathItem{
+			Parameters: openapi3.Parameters{idParam},
+			Get:        &openapi3.Operation{OperationID: fmt.Sprintf("getOrder%d", i), Responses: responses},
+			Put:        &openapi3.Operation{OperationID: fmt.Sprintf("updateOrder%d", i), RequestBody: body, Responses: responses},
+			Patch:      &openapi3.Operation{OperationID: fmt.Sprintf("patchOrder%d", i), RequestBody: body, Responses: responses},
+			Delete:     &openapi3.Operation{OperationID: fmt.Sprintf("deleteOrder%d", i), Responses: responses},
+		}
+		spec.Paths.Set(fmt.Sprintf("/shops%d/orders/{id}", i), pathItem)
+	}
+
+	return spec, parser.FindOperations(spec, "", "", "")
+}
+
+func BenchmarkBuildHTTPRequest_LargeSpec(b *testing.B) {
+	spec, ops := syntheticSpec(1000)
+
+	for b.Loop() {
+		gen := NewGenerator(spec)
+		for _, op := range ops {
+			if _, err := gen.BuildHTTPRequest(op); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkLintExamples_LargeSpec(b *testing.B) {
+	spec, ops := syntheticSpec(1000)
+
+	for b.Loop() {
+		NewGenerator(spec).LintExamples(ops)
+	}
+}
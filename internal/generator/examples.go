@@ -1,25 +1,196 @@
 package generator
 
 import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
+// DefaultMaxDepth is how deep nested schemas are generated when MaxDepth is not set
+const DefaultMaxDepth = 10
+
 // generateExample recursively creates example values for parameters from an OpenAPI schema
 // Using, in order of preference: explicit examples, defaults, or type-based generation.
 func (g *Generator) generateExample(schema *openapi3.Schema) interface{} {
+	return g.generateExampleRef(&openapi3.SchemaRef{Value: schema})
+}
+
+// exampleUsage is what an example is generated for, which decides whether readOnly
+// or writeOnly properties are left out
+type exampleUsage int
+
+const (
+	usageAny      exampleUsage = iota // include all properties
+	usageRequest                      // leave out readOnly properties
+	usageResponse                     // leave out writeOnly properties
+)
+
+// generateExampleRef is like generateExample but keeps track of the $ref the schema
+// was resolved from, which is needed to fill in discriminator values.
+func (g *Generator) generateExampleRef(schemaRef *openapi3.SchemaRef) interface{} {
+	return g.generateFor(usageAny, schemaRef)
+}
+
+// generateRequestExample generates an example to send in a request, without any
+// readOnly properties
+func (g *Generator) generateRequestExample(schemaRef *openapi3.SchemaRef) interface{} {
+	return g.generateFor(usageRequest, schemaRef)
+}
+
+// generateResponseExample generates an example of a response, without any writeOnly
+// properties
+func (g *Generator) generateResponseExample(schemaRef *openapi3.SchemaRef) interface{} {
+	return g.generateFor(usageResponse, schemaRef)
+}
+
+// exampleKey identifies a generated example in the cache: the same schema generated for
+// the same usage with the same settings always gives the same example
+type exampleKey struct {
+	schema       *openapi3.Schema
+	ref          string
+	usage        exampleUsage
+	requiredOnly bool
+	variant      string
+	maxDepth     int
+	arrayItems   int
+	realistic    bool
+}
+
+// generateFor generates an example for the given usage. Large specs use the same component
+// schemas over and over, so examples are cached per resolved schema, unless seeded as the
+// values then depend on the operation. Callers get a copy they are free to modify.
+func (g *Generator) generateFor(usage exampleUsage, schemaRef *openapi3.SchemaRef) interface{} {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return nil
+	}
+
+	if g.rand != nil {
+		return g.generateRoot(usage, schemaRef)
+	}
+
+	key := exampleKey{
+		schema:       schemaRef.Value,
+		ref:          schemaRef.Ref,
+		usage:        usage,
+		requiredOnly: g.requiredOnly,
+		variant:      g.Variant,
+		maxDepth:     g.maxDepth(),
+		arrayItems:   g.ArrayItems,
+		realistic:    g.Realistic,
+	}
+	example, ok := g.examples[key]
+	if !ok {
+		example = g.generateRoot(usage, schemaRef)
+		if g.examples == nil {
+			g.examples = make(map[exampleKey]interface{})
+		}
+		g.examples[key] = example
+	}
+	return deepCopy(example)
+}
+
+// generateRoot generates an example for a schema that isn't nested in another one
+func (g *Generator) generateRoot(usage exampleUsage, schemaRef *openapi3.SchemaRef) interface{} {
+	// start from a clean slate, with only the root schema being visited
+	g.depth = 0
+	g.property = ""
+	g.inheritedRequired = nil
+	g.usage = usage
+	g.visiting = map[*openapi3.Schema]bool{schemaRef.Value: true}
+	defer func() { g.visiting = nil }()
+
+	return g.generateSchema(schemaRef.Value, schemaRef.Ref)
+}
+
+// descend generates an example for a schema nested in the one currently being generated.
+// It returns false instead of recursing when the schema is already being generated further
+// up, i.e. it references itself, or when MaxDepth has been reached. The caller decides on
+// a terminal value in that case.
+func (g *Generator) descend(schemaRef *openapi3.SchemaRef) (interface{}, bool) {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return nil, true
+	}
+
+	schema := schemaRef.Value
+	if g.visiting[schema] || g.depth >= g.maxDepth() {
+		return nil, false
+	}
+
+	if g.visiting == nil {
+		g.visiting = make(map[*openapi3.Schema]bool)
+	}
+	g.visiting[schema] = true
+	g.depth++
+	defer func() {
+		delete(g.visiting, schema)
+		g.depth--
+	}()
+
+	return g.generateSchema(schema, schemaRef.Ref), true
+}
+
+// descendProperty is descend for the value of a named property, which is used to pick
+// realistic values
+func (g *Generator) descendProperty(name string, schemaRef *openapi3.SchemaRef) (interface{}, bool) {
+	parent, parentRequired := g.property, g.inheritedRequired
+	g.property, g.inheritedRequired = name, nil
+	defer func() { g.property, g.inheritedRequired = parent, parentRequired }()
+
+	return g.descend(schemaRef)
+}
+
+// maxDepth returns the configured MaxDepth or the default
+func (g *Generator) maxDepth() int {
+	if g.MaxDepth > 0 {
+		return g.MaxDepth
+	}
+	return DefaultMaxDepth
+}
+
+// generateSchema does the actual work for generateExample, ref is the $ref of the
+// schema or empty for inline schemas
+func (g *Generator) generateSchema(schema *openapi3.Schema, ref string) interface{} {
+	// const is the only valid value
+	if schema.Const != nil {
+		return schema.Const
+	}
+
 	// use the provided example if present
 	if schema.Example != nil {
 		return schema.Example
 	}
 
+	// OpenAPI 3.1 examples, first one or a random one when seeded
+	if len(schema.Examples) > 0 {
+		return schema.Examples[g.intn(len(schema.Examples))]
+	}
+
 	// use default value if present
 	if schema.Default != nil {
 		return schema.Default
 	}
 
-	// for enums, return first value
+	// for enums, return first value, or a random one when seeded
 	if len(schema.Enum) > 0 {
-		return schema.Enum[0]
+		return schema.Enum[g.intn(len(schema.Enum))]
+	}
+
+	// composed schemas
+	if len(schema.AllOf) > 0 {
+		return g.generateAllOf(schema, ref)
+	}
+	if len(schema.OneOf) > 0 {
+		return g.generateOneOf(schema, schema.OneOf)
+	}
+	if len(schema.AnyOf) > 0 {
+		return g.generateOneOf(schema, schema.AnyOf)
 	}
 
 	// check type via helper
@@ -27,95 +198,583 @@ func (g *Generator) generateExample(schema *openapi3.Schema) interface{} {
 
 	switch schemaType {
 	case "string":
-		if schema.Format == "date" {
-			return "2024-01-01"
+		return g.generateString(schema)
+
+	case "integer":
+		return g.generateInteger(schema)
+
+	case "number":
+		return g.generateNumber(schema)
+
+	case "boolean":
+		return g.intn(2) == 1
+
+	case "array":
+		return g.generateArray(schema)
+
+	case "object":
+		return g.generateObject(schema, ref)
+
+	default:
+		// no type specified, infer it from the keywords used
+		if len(schema.Properties) > 0 || len(schema.PatternProperties) > 0 || schema.If != nil {
+			return g.generateObject(schema, ref)
 		}
-		if schema.Format == "date-time" {
-			return "2024-01-01T00:00:00Z"
+		if len(schema.PrefixItems) > 0 {
+			return g.generateArray(schema)
 		}
-		if schema.Format == "email" {
-			return "user@example.com"
+		if schema.Pattern != "" {
+			return g.generateString(schema)
 		}
-		return "string"
+		return nil
+	}
+}
 
-	case "integer":
-		if schema.Min != nil {
-			return int(*schema.Min)
+// generateArray generates the tuple of prefixItems or otherwise items, and makes sure
+// the array has an item matching contains
+func (g *Generator) generateArray(schema *openapi3.Schema) []interface{} {
+	items := []interface{}{}
+	name := singular(g.property)
+
+	if len(schema.PrefixItems) > 0 {
+		for _, prefix := range schema.PrefixItems {
+			item, ok := g.descendProperty(name, prefix)
+			if !ok {
+				// recursive item, stop with the items so far
+				return items
+			}
+			items = append(items, item)
 		}
-		return 0
+	} else if schema.Items != nil && schema.Items.Value != nil {
+		count := g.collectionSize(schema.MinItems, schema.MaxItems)
+		for len(items) < count {
+			item, ok := g.descendProperty(name, schema.Items)
+			if !ok {
+				// recursive item, stop with an empty array
+				return []interface{}{}
+			}
+			if schema.UniqueItems && containsValue(items, item) {
+				if item, ok = g.distinctItem(name, schema.Items, items); !ok {
+					// no more distinct values, e.g. a small enum
+					break
+				}
+			}
+			items = append(items, item)
+		}
+	}
 
-	case "number":
-		if schema.Min != nil {
-			return *schema.Min
+	if schema.Contains != nil && schema.Contains.Value != nil {
+		minContains := 1
+		if schema.MinContains != nil {
+			minContains = int(*schema.MinContains)
 		}
-		return 0.0
 
-	case "boolean":
-		return false
+		matching := 0
+		for _, item := range items {
+			if schema.Contains.Value.VisitJSON(item, openapi3.EnableJSONSchema2020()) == nil {
+				matching++
+			}
+		}
+		for ; matching < minContains; matching++ {
+			item, ok := g.descendProperty(name, schema.Contains)
+			if !ok {
+				break
+			}
+			items = append(items, item)
+		}
+	}
 
-	case "array":
-		if schema.Items != nil && schema.Items.Value != nil {
-			item := g.generateExample(schema.Items.Value)
-			return []interface{}{item}
+	return items
+}
+
+// generateObject generates the properties of an object, including an example for each of
+// the patternProperties, then adds what dependentRequired, dependentSchemas and if/then/else
+// call for
+func (g *Generator) generateObject(schema *openapi3.Schema, ref string) map[string]interface{} {
+	obj := g.generateProperties(schema, ref)
+
+	if !g.requiredOnly {
+		for _, pattern := range slices.Sorted(maps.Keys(schema.PatternProperties)) {
+			key, err := stringFromPattern(pattern, 1, nil, nil)
+			if _, exists := obj[key]; err != nil || exists {
+				continue
+			}
+			if value, ok := g.descendProperty(key, schema.PatternProperties[pattern]); ok {
+				obj[key] = value
+			}
 		}
-		return []interface{}{}
+	}
 
-	case "object":
-		obj := make(map[string]interface{})
+	g.applyPropertyCounts(schema, obj)
+	g.applyDependencies(schema, obj)
+	g.applyConditional(schema, obj)
+
+	return obj
+}
+
+// applyPropertyCounts adds entries to maps, i.e. objects with additionalProperties and no
+// properties of their own, and makes sure the object has between minProperties and
+// maxProperties properties
+func (g *Generator) applyPropertyCounts(schema *openapi3.Schema, obj map[string]interface{}) {
+	additional := schema.AdditionalProperties
+	isMap := len(schema.Properties) == 0 && (additional.Schema != nil || (additional.Has != nil && *additional.Has))
+
+	target := uint64(len(obj))
+	if isMap && len(obj) == 0 {
+		target = uint64(g.collectionSize(0, nil))
+	}
+	target = max(target, schema.MinProps)
 
-		// generate for all properties
-		for propName, propSchema := range schema.Properties {
-			if propSchema.Value != nil {
-				obj[propName] = g.generateExample(propSchema.Value)
+	// declared properties left out of a minimal body come first
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		if uint64(len(obj)) >= target {
+			break
+		}
+		propSchema := schema.Properties[name]
+		if _, present := obj[name]; present || propSchema.Value == nil || g.leaveOut(propSchema.Value) {
+			continue
+		}
+		if value, ok := g.descendProperty(name, propSchema); ok {
+			obj[name] = value
+		}
+	}
+
+	// then entries for additionalProperties, named key, key2, key3...
+	if additional.Has == nil || *additional.Has {
+		for i := 1; uint64(len(obj)) < target; i++ {
+			key := "key"
+			if i > 1 {
+				key = fmt.Sprintf("key%d", i)
+			}
+			if _, present := obj[key]; present {
+				continue
 			}
+
+			var value interface{} = "value"
+			if additional.Schema != nil {
+				var ok bool
+				if value, ok = g.descendProperty(singular(g.property), additional.Schema); !ok {
+					break
+				}
+			}
+			obj[key] = value
 		}
+	}
 
-		// if no properties but additionalProperties, show example
-		if len(obj) == 0 && schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has {
-			obj["key"] = "value"
+	// drop optional properties over maxProperties
+	if schema.MaxProps != nil {
+		names := slices.Sorted(maps.Keys(obj))
+		for i := len(names) - 1; i >= 0 && uint64(len(obj)) > *schema.MaxProps; i-- {
+			if !slices.Contains(schema.Required, names[i]) {
+				delete(obj, names[i])
+			}
 		}
+	}
+}
 
-		return obj
+// collectionSize decides how many array items or map entries to generate: ArrayItems,
+// a random number when seeded or else one, within the minimum and maximum
+func (g *Generator) collectionSize(minSize uint64, maxSize *uint64) int {
+	size := 1
+	if g.ArrayItems > 0 {
+		size = g.ArrayItems
+	} else if g.rand != nil {
+		size = 1 + g.intn(3)
+	}
 
-	default:
-		// no type specified, try object
-		if len(schema.Properties) > 0 {
-			obj := make(map[string]interface{})
-			for propName, propSchema := range schema.Properties {
-				if propSchema.Value != nil {
-					obj[propName] = g.generateExample(propSchema.Value)
+	size = max(size, int(minSize))
+	if maxSize != nil {
+		size = min(size, int(*maxSize))
+	}
+	return size
+}
+
+// maxUniqueAttempts is how often an array item is regenerated to find a distinct value
+const maxUniqueAttempts = 20
+
+// distinctItem generates an array item that isn't in items yet, for uniqueItems. It
+// regenerates the item with random values, seeded so the result is reproducible, and
+// falls back to numbering plain strings.
+func (g *Generator) distinctItem(name string, itemSchema *openapi3.SchemaRef, items []interface{}) (interface{}, bool) {
+	saved := g.rand
+	defer func() { g.rand = saved }()
+
+	for attempt := range maxUniqueAttempts {
+		g.rand = rand.New(rand.NewPCG(uint64(len(items)), uint64(attempt)))
+		item, ok := g.descendProperty(name, itemSchema)
+		if ok && !containsValue(items, item) {
+			return item, true
+		}
+	}
+	g.rand = saved
+
+	// plain strings don't vary at random
+	schema := itemSchema.Value
+	if getSchemaType(schema) == "string" && schema.Pattern == "" && len(schema.Enum) == 0 {
+		base, _ := g.descendProperty(name, itemSchema)
+		if s, ok := base.(string); ok {
+			item := fitLength(fmt.Sprintf("%s%d", s, len(items)+1), schema.MinLength, nil)
+			if schema.MaxLength == nil || uint64(utf8.RuneCountInString(item)) <= *schema.MaxLength {
+				if !containsValue(items, item) {
+					return item, true
 				}
 			}
-			return obj
 		}
-		return nil
 	}
+
+	return nil, false
 }
 
-// helper to extract primary type from *openapi3.Types
-func getSchemaType(schema *openapi3.Schema) string {
-	if schema.Type == nil {
-		return ""
+// containsValue checks whether items contains a value equal to item
+func containsValue(items []interface{}, item interface{}) bool {
+	for _, existing := range items {
+		if reflect.DeepEqual(existing, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyDependencies adds the properties that are required by, or the schemas that apply
+// because of, the properties already in obj. Added properties can in turn have dependencies
+// of their own, so this repeats until nothing changes.
+func (g *Generator) applyDependencies(schema *openapi3.Schema, obj map[string]interface{}) {
+	applied := make(map[string]bool)
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, name := range slices.Sorted(maps.Keys(schema.DependentRequired)) {
+			if _, present := obj[name]; !present {
+				continue
+			}
+			for _, dependent := range schema.DependentRequired[name] {
+				if _, present := obj[dependent]; !present {
+					obj[dependent] = g.propertyValue(schema, dependent)
+					changed = true
+				}
+			}
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(schema.DependentSchemas)) {
+			if _, present := obj[name]; !present || applied[name] {
+				continue
+			}
+			applied[name] = true
+			if dependent, ok := g.descend(schema.DependentSchemas[name]); ok {
+				if dependentObj, ok := dependent.(map[string]interface{}); ok {
+					for k, v := range dependentObj {
+						if _, present := obj[k]; !present {
+							obj[k] = v
+							changed = true
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// applyConditional merges the then or else schema into obj, depending on whether obj
+// matches the if schema
+func (g *Generator) applyConditional(schema *openapi3.Schema, obj map[string]interface{}) {
+	if schema.If == nil || schema.If.Value == nil {
+		return
+	}
+
+	branch := schema.Else
+	if schema.If.Value.VisitJSON(obj, openapi3.EnableJSONSchema2020()) == nil {
+		branch = schema.Then
+	}
+	if branch == nil || branch.Value == nil {
+		return
+	}
+
+	// the branch's properties are more specific, so they replace the generated ones
+	if value, ok := g.descend(branch); ok {
+		if branchObj, ok := value.(map[string]interface{}); ok {
+			for k, v := range branchObj {
+				if _, present := obj[k]; !present || v != nil {
+					obj[k] = v
+				}
+			}
+		}
+	}
+
+	// the branch can also require properties declared on the schema itself
+	for _, name := range branch.Value.Required {
+		if _, present := obj[name]; !present {
+			obj[name] = g.propertyValue(schema, name)
+		}
 	}
+}
 
-	// Type is *Types which is a slice wrapper
-	if schema.Type.Includes("string") {
+// propertyValue generates a value for a single named property of the schema, for
+// properties that aren't declared it is a plain string
+func (g *Generator) propertyValue(schema *openapi3.Schema, name string) interface{} {
+	propSchema, ok := schema.Properties[name]
+	if !ok || propSchema.Value == nil {
 		return "string"
 	}
-	if schema.Type.Includes("integer") {
-		return "integer"
+	value, _ := g.descendProperty(name, propSchema)
+	return value
+}
+
+// generateProperties generates an object with an example for each of the schema's
+// properties, or only the required ones when generating a minimal body. Optional
+// properties that would recurse are omitted, required ones are null.
+func (g *Generator) generateProperties(schema *openapi3.Schema, ref string) map[string]interface{} {
+	obj := make(map[string]interface{})
+
+	// sorted, so seeded random values are always used in the same order
+	for _, propName := range slices.Sorted(maps.Keys(schema.Properties)) {
+		propSchema := schema.Properties[propName]
+		if propSchema.Value == nil || g.leaveOut(propSchema.Value) {
+			continue
+		}
+		required := slices.Contains(schema.Required, propName) || slices.Contains(g.inheritedRequired, propName)
+		if g.requiredOnly && !required {
+			continue
+		}
+		value, ok := g.descendProperty(propName, propSchema)
+		if !ok && !required {
+			continue
+		}
+		obj[propName] = value
+	}
+	g.applyDiscriminator(schema, ref, obj)
+
+	return obj
+}
+
+// leaveOut checks whether a property is left out for the current usage: readOnly
+// properties are set by the server and writeOnly ones are never sent back
+func (g *Generator) leaveOut(property *openapi3.Schema) bool {
+	switch g.usage {
+	case usageRequest:
+		return property.ReadOnly
+	case usageResponse:
+		return property.WriteOnly
+	}
+	return false
+}
+
+// generateAllOf merges the examples of all allOf members, and any properties declared
+// next to the allOf, into a single object. Non-object members only count when nothing
+// else produced an object.
+func (g *Generator) generateAllOf(schema *openapi3.Schema, ref string) interface{} {
+	merged := make(map[string]interface{})
+	var scalar interface{}
+	var discriminators []*openapi3.Discriminator
+
+	// required can list properties declared in any of the members
+	parentRequired := g.inheritedRequired
+	g.inheritedRequired = slices.Concat(parentRequired, schema.Required)
+	for _, member := range schema.AllOf {
+		if member.Value != nil {
+			g.inheritedRequired = append(g.inheritedRequired, member.Value.Required...)
+		}
+	}
+	defer func() { g.inheritedRequired = parentRequired }()
+
+	for _, member := range schema.AllOf {
+		if member.Value == nil {
+			continue
+		}
+		value, ok := g.descend(member)
+		if !ok {
+			continue
+		}
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			if scalar == nil {
+				scalar = value
+			}
+			continue
+		}
+		maps.Copy(merged, obj)
+
+		// a base schema with a discriminator identifies the composing schema, e.g. Cat: allOf [Pet, {...}]
+		if member.Value.Discriminator != nil {
+			discriminators = append(discriminators, member.Value.Discriminator)
+		}
 	}
-	if schema.Type.Includes("number") {
-		return "number"
+
+	// properties declared alongside allOf
+	sibling := *schema
+	sibling.AllOf = nil
+	if obj, ok := g.generateSchema(&sibling, ref).(map[string]interface{}); ok {
+		maps.Copy(merged, obj)
 	}
-	if schema.Type.Includes("boolean") {
-		return "boolean"
+
+	if len(merged) == 0 && scalar != nil {
+		return scalar
 	}
-	if schema.Type.Includes("array") {
-		return "array"
+
+	for _, d := range discriminators {
+		if value, ok := discriminatorValue(d, ref); ok {
+			merged[d.PropertyName] = value
+		}
 	}
-	if schema.Type.Includes("object") {
-		return "object"
+
+	return merged
+}
+
+// generateOneOf generates an example for one of the branches of a oneOf or anyOf, using
+// the branch selected with Variant or else the first one. Properties declared alongside
+// the oneOf are merged in and the discriminator, if any, is set to match the branch.
+func (g *Generator) generateOneOf(schema *openapi3.Schema, branches openapi3.SchemaRefs) interface{} {
+	branch := g.selectBranch(schema, branches)
+	if branch == nil {
+		return nil
+	}
+
+	value, ok := g.descend(branch)
+	if !ok {
+		// the preferred branch recurses, fall back to the first one that doesn't
+		for _, other := range branches {
+			if other == branch || other.Value == nil {
+				continue
+			}
+			if value, ok = g.descend(other); ok {
+				branch = other
+				break
+			}
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	// the branch's example is the spec's own value, which must be left alone
+	obj = deepCopy(obj).(map[string]interface{})
+
+	// properties declared alongside oneOf/anyOf
+	sibling := *schema
+	sibling.OneOf = nil
+	sibling.AnyOf = nil
+	sibling.Discriminator = nil
+	if common, ok := g.generateSchema(&sibling, "").(map[string]interface{}); ok {
+		for k, v := range common {
+			if _, exists := obj[k]; !exists {
+				obj[k] = v
+			}
+		}
+	}
+
+	if d := schema.Discriminator; d != nil {
+		if value, ok := discriminatorValue(d, branch.Ref); ok {
+			obj[d.PropertyName] = value
+		}
+	}
+
+	return obj
+}
+
+// selectBranch picks the oneOf/anyOf branch to generate. Variant can name a branch by
+// its schema name, title or discriminator mapping key, otherwise the first branch is used.
+func (g *Generator) selectBranch(schema *openapi3.Schema, branches openapi3.SchemaRefs) *openapi3.SchemaRef {
+	if g.Variant != "" {
+		for _, branch := range branches {
+			if branch.Value == nil {
+				continue
+			}
+			if componentName(branch.Ref) == g.Variant || branch.Value.Title == g.Variant {
+				return branch
+			}
+			if schema.Discriminator != nil {
+				if mapped, ok := schema.Discriminator.Mapping[g.Variant]; ok && branch.Ref != "" && componentName(mapped.Ref) == componentName(branch.Ref) {
+					return branch
+				}
+			}
+		}
+	}
+
+	for _, branch := range branches {
+		if branch.Value != nil {
+			return branch
+		}
+	}
+	return nil
+}
+
+// applyDiscriminator sets the discriminator property of an object generated from a
+// schema that declares one, based on the schema's own $ref
+func (g *Generator) applyDiscriminator(schema *openapi3.Schema, ref string, obj map[string]interface{}) {
+	if schema.Discriminator == nil {
+		return
+	}
+	if value, ok := discriminatorValue(schema.Discriminator, ref); ok {
+		obj[schema.Discriminator.PropertyName] = value
+	}
+}
+
+// discriminatorValue returns the value that identifies the schema at ref: the mapping
+// key pointing at it or, when there is no explicit mapping, the schema name itself
+func discriminatorValue(d *openapi3.Discriminator, ref string) (string, bool) {
+	if ref == "" {
+		return "", false
+	}
+	name := componentName(ref)
+
+	// sort keys so that aliases mapping to the same schema give a stable result
+	keys := make([]string, 0, len(d.Mapping))
+	for key := range d.Mapping {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if componentName(d.Mapping[key].Ref) == name {
+			return key, true
+		}
+	}
+
+	return name, true
+}
+
+// componentName returns the last segment of a $ref, e.g. Pet for #/components/schemas/Pet
+func componentName(ref string) string {
+	if i := strings.LastIndex(ref, "/"); i >= 0 {
+		return ref[i+1:]
+	}
+	return ref
+}
+
+// deepCopy copies the maps and slices of a generated example, so changes to the copy
+// don't affect the original
+func deepCopy(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			c[k] = deepCopy(value)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, value := range v {
+			c[i] = deepCopy(value)
+		}
+		return c
+	}
+	return v
+}
+
+// helper to extract primary type from *openapi3.Types, OpenAPI 3.1 type arrays use the
+// first type listed other than null
+func getSchemaType(schema *openapi3.Schema) string {
+	if schema.Type == nil {
+		return ""
+	}
+
+	for _, t := range *schema.Type {
+		if t != "null" {
+			return t
+		}
 	}
 	if schema.Type.Includes("null") {
 		return "null"
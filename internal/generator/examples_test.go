@@ -1,9 +1,12 @@
 package generator
 
 import (
+	"encoding/json"
+	"slices"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/kalli/openapi-http/internal/parser"
 )
 
 func TestGenerateExample_String(t *testing.T) {
@@ -28,6 +31,12 @@ func TestGenerateExample_StringWithFormat(t *testing.T) {
 		{"date", "2024-01-01"},
 		{"date-time", "2024-01-01T00:00:00Z"},
 		{"email", "user@example.com"},
+		{"uuid", "3fa85f64-5717-4562-b3fc-2c963f66afa6"},
+		{"uri", "https://example.com/resource"},
+		{"ipv4", "192.0.2.1"},
+		{"byte", "ZXhhbXBsZQ=="},
+		{"country-code", "US"},
+		{"unknown-format", "string"},
 	}
 
 	gen := &Generator{}
@@ -367,3 +376,670 @@ func TestGetSchemaType(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateExample_AllOf(t *testing.T) {
+	gen := &Generator{}
+
+	schema := &openapi3.Schema{
+		AllOf: openapi3.SchemaRefs{
+			&openapi3.SchemaRef{
+				Value: &openapi3.Schema{
+					Type: &openapi3.Types{"object"},
+					Properties: openapi3.Schemas{
+						"id": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Example: 1}},
+					},
+				},
+			},
+			&openapi3.SchemaRef{
+				Value: &openapi3.Schema{
+					Type: &openapi3.Types{"object"},
+					Properties: openapi3.Schemas{
+						"name": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Example: "Rex"}},
+					},
+				},
+			},
+		},
+		Properties: openapi3.Schemas{
+			"age": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Example: 3}},
+		},
+	}
+
+	result := gen.generateExample(schema)
+
+	obj, ok := result.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected object, got: %T", result)
+	}
+
+	if obj["id"] != 1 || obj["name"] != "Rex" || obj["age"] != 3 {
+		t.Errorf("expected merged allOf members, got: %v", obj)
+	}
+}
+
+func TestGenerateExample_OneOfUsesFirstBranch(t *testing.T) {
+	gen := &Generator{}
+
+	schema := &openapi3.Schema{
+		OneOf: openapi3.SchemaRefs{
+			&openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Example: "first"}},
+			&openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Example: "second"}},
+		},
+	}
+
+	result := gen.generateExample(schema)
+
+	if result != "first" {
+		t.Errorf("expected first oneOf branch, got: %v", result)
+	}
+}
+
+func TestGenerateExample_AnyOfWithVariant(t *testing.T) {
+	gen := &Generator{Variant: "Dog"}
+
+	schema := &openapi3.Schema{
+		AnyOf: openapi3.SchemaRefs{
+			&openapi3.SchemaRef{Ref: "#/components/schemas/Cat", Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Example: "cat"}},
+			&openapi3.SchemaRef{Ref: "#/components/schemas/Dog", Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Example: "dog"}},
+		},
+	}
+
+	result := gen.generateExample(schema)
+
+	if result != "dog" {
+		t.Errorf("expected selected anyOf branch, got: %v", result)
+	}
+}
+
+func TestGenerateExample_OneOfDiscriminator(t *testing.T) {
+	petType := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	cat := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Cat",
+		Value: &openapi3.Schema{
+			Type:       &openapi3.Types{"object"},
+			Properties: openapi3.Schemas{"petType": petType},
+		},
+	}
+	dog := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Dog",
+		Value: &openapi3.Schema{
+			Type:       &openapi3.Types{"object"},
+			Properties: openapi3.Schemas{"petType": petType},
+		},
+	}
+
+	schema := &openapi3.Schema{
+		OneOf: openapi3.SchemaRefs{cat, dog},
+		Discriminator: &openapi3.Discriminator{
+			PropertyName: "petType",
+			Mapping: map[string]openapi3.MappingRef{
+				"cat": {Ref: "#/components/schemas/Cat"},
+				"dog": {Ref: "#/components/schemas/Dog"},
+			},
+		},
+	}
+
+	tests := []struct {
+		variant  string
+		expected string
+	}{
+		{"", "cat"},
+		{"dog", "dog"},
+		{"Dog", "dog"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variant, func(t *testing.T) {
+			gen := &Generator{Variant: tt.variant}
+
+			obj, ok := gen.generateExample(schema).(map[string]interface{})
+			if !ok {
+				t.Fatal("expected object")
+			}
+
+			if obj["petType"] != tt.expected {
+				t.Errorf("expected petType %q, got: %v", tt.expected, obj["petType"])
+			}
+		})
+	}
+}
+
+func TestGenerateExample_OneOfLeavesBranchExample(t *testing.T) {
+	cat := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Cat",
+		Value: &openapi3.Schema{
+			Type:    &openapi3.Types{"object"},
+			Example: map[string]interface{}{"meow": true},
+		},
+	}
+
+	schema := &openapi3.Schema{
+		Type:       &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{"common": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}},
+		OneOf:      openapi3.SchemaRefs{cat},
+		Discriminator: &openapi3.Discriminator{
+			PropertyName: "petType",
+			Mapping: map[string]openapi3.MappingRef{
+				"cat": {Ref: "#/components/schemas/Cat"},
+			},
+		},
+	}
+
+	for _, seeded := range []bool{false, true} {
+		gen := &Generator{}
+		if seeded {
+			gen.SetSeed(1)
+		}
+
+		obj, ok := gen.generateExample(schema).(map[string]interface{})
+		if !ok || obj["petType"] != "cat" || obj["meow"] != true {
+			t.Fatalf("expected the cat example with petType, got: %v", obj)
+		}
+
+		if example := cat.Value.Example.(map[string]interface{}); len(example) != 1 {
+			t.Errorf("seeded %v: expected the branch example to be left alone, got: %v", seeded, example)
+		}
+	}
+}
+
+func TestGenerateExample_AllOfDiscriminator(t *testing.T) {
+	gen := &Generator{}
+
+	pet := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Pet",
+		Value: &openapi3.Schema{
+			Type: &openapi3.Types{"object"},
+			Properties: openapi3.Schemas{
+				"petType": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+			},
+			Discriminator: &openapi3.Discriminator{PropertyName: "petType"},
+		},
+	}
+	cat := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Cat",
+		Value: &openapi3.Schema{
+			AllOf: openapi3.SchemaRefs{
+				pet,
+				&openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{"object"},
+						Properties: openapi3.Schemas{
+							"huntingSkill": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Example: "lazy"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	obj, ok := gen.generateExampleRef(cat).(map[string]interface{})
+	if !ok {
+		t.Fatal("expected object")
+	}
+
+	if obj["petType"] != "Cat" {
+		t.Errorf("expected petType 'Cat', got: %v", obj["petType"])
+	}
+
+	if obj["huntingSkill"] != "lazy" {
+		t.Errorf("expected huntingSkill from allOf member, got: %v", obj["huntingSkill"])
+	}
+}
+
+func TestGenerateExample_RecursiveSchema(t *testing.T) {
+	gen := &Generator{}
+
+	node := &openapi3.Schema{
+		Type:     &openapi3.Types{"object"},
+		Required: []string{"name"},
+	}
+	nodeRef := &openapi3.SchemaRef{Ref: "#/components/schemas/Node", Value: node}
+	node.Properties = openapi3.Schemas{
+		"name":   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Example: "root"}},
+		"parent": nodeRef,
+		"children": &openapi3.SchemaRef{
+			Value: &openapi3.Schema{
+				Type:  &openapi3.Types{"array"},
+				Items: nodeRef,
+			},
+		},
+	}
+
+	result := gen.generateExampleRef(nodeRef)
+
+	obj, ok := result.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected object, got: %T", result)
+	}
+
+	if obj["name"] != "root" {
+		t.Errorf("expected name 'root', got: %v", obj["name"])
+	}
+
+	// optional self reference is omitted
+	if _, ok := obj["parent"]; ok {
+		t.Errorf("expected recursive optional property to be omitted, got: %v", obj["parent"])
+	}
+
+	// recursive array items stop with an empty array
+	children, ok := obj["children"].([]interface{})
+	if !ok || len(children) != 0 {
+		t.Errorf("expected empty children array, got: %v", obj["children"])
+	}
+}
+
+func TestGenerateExample_RequiredRecursiveProperty(t *testing.T) {
+	gen := &Generator{}
+
+	category := &openapi3.Schema{
+		Type:     &openapi3.Types{"object"},
+		Required: []string{"parent"},
+	}
+	category.Properties = openapi3.Schemas{
+		"parent": &openapi3.SchemaRef{Ref: "#/components/schemas/Category", Value: category},
+	}
+
+	obj, ok := gen.generateExample(category).(map[string]interface{})
+	if !ok {
+		t.Fatal("expected object")
+	}
+
+	parent, ok := obj["parent"]
+	if !ok || parent != nil {
+		t.Errorf("expected required recursive property to be null, got: %v", parent)
+	}
+}
+
+func TestGenerateExample_MaxDepth(t *testing.T) {
+	gen := &Generator{MaxDepth: 1}
+
+	schema := &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"user": &openapi3.SchemaRef{
+				Value: &openapi3.Schema{
+					Type: &openapi3.Types{"object"},
+					Properties: openapi3.Schemas{
+						"name": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+					},
+				},
+			},
+		},
+	}
+
+	obj, ok := gen.generateExample(schema).(map[string]interface{})
+	if !ok {
+		t.Fatal("expected object")
+	}
+
+	user, ok := obj["user"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected user object, got: %v", obj["user"])
+	}
+
+	if len(user) != 0 {
+		t.Errorf("expected properties beyond max depth to be omitted, got: %v", user)
+	}
+}
+
+func TestGenerateExample_ReadOnlyWriteOnly(t *testing.T) {
+	gen := &Generator{}
+
+	user := &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"id":       &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, ReadOnly: true}},
+			"name":     &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+			"password": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, WriteOnly: true}},
+		},
+	}
+	schema := &openapi3.SchemaRef{
+		Value: &openapi3.Schema{
+			AllOf: openapi3.SchemaRefs{
+				&openapi3.SchemaRef{Value: user},
+				&openapi3.SchemaRef{Value: &openapi3.Schema{
+					Type: &openapi3.Types{"object"},
+					Properties: openapi3.Schemas{
+						"createdAt": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, ReadOnly: true}},
+						"friends": &openapi3.SchemaRef{Value: &openapi3.Schema{
+							Type:  &openapi3.Types{"array"},
+							Items: &openapi3.SchemaRef{Value: user},
+						}},
+					},
+				}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		generate func(*openapi3.SchemaRef) interface{}
+		present  []string
+		absent   []string
+	}{
+		{"request", gen.generateRequestExample, []string{"name", "password", "friends"}, []string{"id", "createdAt"}},
+		{"response", gen.generateResponseExample, []string{"id", "name", "createdAt", "friends"}, []string{"password"}},
+		{"any", gen.generateExampleRef, []string{"id", "name", "password", "createdAt", "friends"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, ok := tt.generate(schema).(map[string]interface{})
+			if !ok {
+				t.Fatal("expected object")
+			}
+
+			friends, ok := obj["friends"].([]interface{})
+			if !ok || len(friends) != 1 {
+				t.Fatalf("expected friends array, got: %v", obj["friends"])
+			}
+			friend := friends[0].(map[string]interface{})
+
+			for _, name := range tt.present {
+				if _, ok := obj[name]; !ok {
+					t.Errorf("expected %s to be present, got: %v", name, obj)
+				}
+			}
+			for _, name := range tt.absent {
+				if _, ok := obj[name]; ok {
+					t.Errorf("expected %s to be left out, got: %v", name, obj)
+				}
+				// nested objects in arrays are filtered too
+				if _, ok := friend[name]; ok {
+					t.Errorf("expected %s to be left out of nested items, got: %v", name, friend)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateExample_OpenAPI31(t *testing.T) {
+	spec, err := parser.LoadSpec("../../test/openapi31.yml")
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+
+	gen := NewGenerator(spec)
+	order := spec.Components.Schemas["Order"]
+
+	obj, ok := gen.generateExampleRef(order).(map[string]interface{})
+	if !ok {
+		t.Fatal("expected object")
+	}
+
+	// const and examples
+	if obj["kind"] != "order" {
+		t.Errorf("expected const kind 'order', got: %v", obj["kind"])
+	}
+	if obj["note"] != "Leave at the door" {
+		t.Errorf("expected first of examples, got: %v", obj["note"])
+	}
+
+	// prefixItems
+	location, ok := obj["location"].([]interface{})
+	if !ok || len(location) != 2 || location[1] != 5.0 {
+		t.Errorf("expected tuple from prefixItems, got: %v", obj["location"])
+	}
+
+	// contains
+	items, ok := obj["items"].([]interface{})
+	if !ok || !slices.Contains(items, interface{}("gift-wrap")) {
+		t.Errorf("expected items to contain 'gift-wrap', got: %v", obj["items"])
+	}
+
+	// patternProperties
+	metadata, ok := obj["metadata"].(map[string]interface{})
+	if !ok || metadata["x-a"] != "string" {
+		t.Errorf("expected a property matching the pattern, got: %v", obj["metadata"])
+	}
+
+	// dependentRequired and dependentSchemas
+	if _, ok := obj["billingAddress"]; !ok {
+		t.Errorf("expected billingAddress required by creditCard, got: %v", obj)
+	}
+	if obj["cvc"] != "000" {
+		t.Errorf("expected cvc from dependent schema, got: %v", obj["cvc"])
+	}
+
+	// if/then/else
+	if obj["postalCode"] != "00000" {
+		t.Errorf("expected postalCode from the then branch, got: %v", obj["postalCode"])
+	}
+
+	if err := order.Value.VisitJSON(obj, openapi3.EnableJSONSchema2020()); err != nil {
+		t.Errorf("generated example doesn't validate: %v", err)
+	}
+}
+
+func TestGenerateExample_ConditionalElse(t *testing.T) {
+	gen := &Generator{}
+
+	var schema openapi3.Schema
+	err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {"country": {"type": "string", "default": "NL"}},
+		"if": {"properties": {"country": {"const": "US"}}},
+		"then": {"required": ["state"]},
+		"else": {"properties": {"province": {"type": "string", "example": "Utrecht"}}}
+	}`), &schema)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	obj, ok := gen.generateExample(&schema).(map[string]interface{})
+	if !ok {
+		t.Fatal("expected object")
+	}
+
+	if obj["province"] != "Utrecht" {
+		t.Errorf("expected province from the else branch, got: %v", obj)
+	}
+	if _, ok := obj["state"]; ok {
+		t.Errorf("expected no state from the then branch, got: %v", obj)
+	}
+}
+
+func TestGetSchemaType_TypeArrays(t *testing.T) {
+	tests := []struct {
+		name     string
+		types    *openapi3.Types
+		expected string
+	}{
+		{"nullable string", &openapi3.Types{"string", "null"}, "string"},
+		{"null first", &openapi3.Types{"null", "integer"}, "integer"},
+		{"declared order", &openapi3.Types{"integer", "string"}, "integer"},
+		{"only null", &openapi3.Types{"null"}, "null"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getSchemaType(&openapi3.Schema{Type: tt.types})
+
+			if result != tt.expected {
+				t.Errorf("expected %s, got: %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateExample_ArraySize(t *testing.T) {
+	two, four := uint64(2), uint64(4)
+
+	tests := []struct {
+		name       string
+		arrayItems int
+		minItems   uint64
+		maxItems   *uint64
+		expected   int
+	}{
+		{"default", 0, 0, nil, 1},
+		{"array items", 3, 0, nil, 3},
+		{"min items", 0, 2, nil, 2},
+		{"max items", 3, 0, &two, 2},
+		{"min items above array items", 2, 3, &four, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := &Generator{ArrayItems: tt.arrayItems}
+
+			schema := &openapi3.Schema{
+				Type:     &openapi3.Types{"array"},
+				MinItems: tt.minItems,
+				MaxItems: tt.maxItems,
+				Items:    &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}},
+			}
+
+			arr, ok := gen.generateExample(schema).([]interface{})
+			if !ok {
+				t.Fatal("expected array")
+			}
+
+			if len(arr) != tt.expected {
+				t.Errorf("expected %d items, got: %v", tt.expected, arr)
+			}
+		})
+	}
+}
+
+func TestGenerateExample_UniqueItems(t *testing.T) {
+	gen := &Generator{}
+
+	maxVal := 100.0
+	tests := []struct {
+		name     string
+		items    *openapi3.Schema
+		expected int
+	}{
+		{"integers", &openapi3.Schema{Type: &openapi3.Types{"integer"}, Max: &maxVal}, 3},
+		{"enum", &openapi3.Schema{Type: &openapi3.Types{"string"}, Enum: []interface{}{"a", "b", "c", "d"}}, 3},
+		{"plain strings", &openapi3.Schema{Type: &openapi3.Types{"string"}}, 3},
+		{"too few distinct values", &openapi3.Schema{Type: &openapi3.Types{"boolean"}}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := &openapi3.Schema{
+				Type:        &openapi3.Types{"array"},
+				MinItems:    3,
+				UniqueItems: true,
+				Items:       &openapi3.SchemaRef{Value: tt.items},
+			}
+
+			arr, ok := gen.generateExample(schema).([]interface{})
+			if !ok {
+				t.Fatal("expected array")
+			}
+
+			if len(arr) != tt.expected {
+				t.Fatalf("expected %d items, got: %v", tt.expected, arr)
+			}
+
+			for i := range arr {
+				for j := i + 1; j < len(arr); j++ {
+					if arr[i] == arr[j] {
+						t.Errorf("expected unique items, got: %v", arr)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateExample_Map(t *testing.T) {
+	gen := &Generator{}
+
+	schema := &openapi3.Schema{
+		Type:     &openapi3.Types{"object"},
+		MinProps: 2,
+		AdditionalProperties: openapi3.AdditionalProperties{
+			Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Example: 7}},
+		},
+	}
+
+	obj, ok := gen.generateExample(schema).(map[string]interface{})
+	if !ok {
+		t.Fatal("expected object")
+	}
+
+	if len(obj) != 2 || obj["key"] != 7 || obj["key2"] != 7 {
+		t.Errorf("expected two entries using the additionalProperties schema, got: %v", obj)
+	}
+}
+
+func TestGenerateExample_MinMaxProperties(t *testing.T) {
+	one := uint64(1)
+	properties := openapi3.Schemas{
+		"a": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+		"b": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+		"c": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+	}
+
+	t.Run("max properties", func(t *testing.T) {
+		gen := &Generator{}
+		schema := &openapi3.Schema{
+			Type:       &openapi3.Types{"object"},
+			Required:   []string{"c"},
+			MaxProps:   &one,
+			Properties: properties,
+		}
+
+		obj := gen.generateExample(schema).(map[string]interface{})
+
+		if _, ok := obj["c"]; len(obj) != 1 || !ok {
+			t.Errorf("expected only required property c, got: %v", obj)
+		}
+	})
+
+	t.Run("min properties in a minimal body", func(t *testing.T) {
+		gen := &Generator{requiredOnly: true}
+		schema := &openapi3.Schema{
+			Type:       &openapi3.Types{"object"},
+			Required:   []string{"c"},
+			MinProps:   2,
+			Properties: properties,
+		}
+
+		obj := gen.generateExample(schema).(map[string]interface{})
+
+		if len(obj) != 2 {
+			t.Errorf("expected 2 properties, got: %v", obj)
+		}
+	})
+}
+
+func TestGenerateExample_Cached(t *testing.T) {
+	schema := &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"id":   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "x-id"}},
+			"tags": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}}},
+		},
+	}
+
+	gen := &Generator{}
+	first := gen.generateExample(schema).(map[string]interface{})
+	if len(gen.examples) != 1 {
+		t.Fatalf("expected the example to be cached, got %d entries", len(gen.examples))
+	}
+
+	// changes to a returned example don't leak into later ones
+	first["id"] = "changed"
+	first["tags"].([]interface{})[0] = "changed"
+
+	second := gen.generateExample(schema).(map[string]interface{})
+	if second["id"] != "string" || second["tags"].([]interface{})[0] != "string" {
+		t.Errorf("expected an unchanged example, got: %v", second)
+	}
+
+	// settings are part of the cache key
+	gen.ArrayItems = 2
+	if tags := gen.generateExample(schema).(map[string]interface{})["tags"].([]interface{}); len(tags) != 2 {
+		t.Errorf("expected 2 tags after changing ArrayItems, got: %v", tags)
+	}
+
+	// and registering a format invalidates the cache
+	gen.RegisterFormat("x-id", "id-123")
+	if id := gen.generateExample(schema).(map[string]interface{})["id"]; id != "id-123" {
+		t.Errorf("expected registered format value, got: %v", id)
+	}
+}
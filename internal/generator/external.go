@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ExternalValueMode controls how examples given by externalValue end up in request bodies
+type ExternalValueMode string
+
+const (
+	ExternalInline ExternalValueMode = "inline" // the content of the file, as is
+	ExternalFile   ExternalValueMode = "file"   // a reference to the file
+)
+
+// externalClient fetches external values from URLs
+var externalClient = &http.Client{Timeout: 30 * time.Second}
+
+// externalBody returns the request body for an example with an externalValue: its content,
+// or a reference to the file with ExternalFile. Binary content can't be inlined so it is
+// always referenced, and URLs can't be referenced so they are always inlined.
+func (g *Generator) externalBody(externalValue string) (string, error) {
+	location, isURL, err := g.resolveExternalValue(externalValue)
+	if err != nil {
+		return "", fmt.Errorf("externalValue %s: %w", externalValue, err)
+	}
+
+	if g.ExternalValues == ExternalFile && !isURL {
+		return g.fileRef(location), nil
+	}
+
+	content, err := g.loadExternalValue(location, isURL)
+	if err != nil {
+		return "", fmt.Errorf("externalValue %s: %w", externalValue, err)
+	}
+	if !utf8.Valid(content) {
+		if isURL {
+			return "", fmt.Errorf("externalValue %s: binary content can't be inlined", externalValue)
+		}
+		return g.fileRef(location), nil
+	}
+	return strings.TrimSuffix(string(content), "\n"), nil
+}
+
+// resolveExternalValue resolves an externalValue against SpecLocation, returning a file
+// path or a URL
+func (g *Generator) resolveExternalValue(externalValue string) (string, bool, error) {
+	ref, err := url.Parse(externalValue)
+	if err != nil {
+		return "", false, err
+	}
+
+	switch ref.Scheme {
+	case "http", "https":
+		return ref.String(), true, nil
+	case "file":
+		return filepath.FromSlash(ref.Path), false, nil
+	case "":
+	default:
+		return "", false, fmt.Errorf("unsupported scheme %s", ref.Scheme)
+	}
+
+	// relative to a spec loaded from a URL
+	if base, err := url.Parse(g.SpecLocation); err == nil && (base.Scheme == "http" || base.Scheme == "https") {
+		return base.ResolveReference(ref).String(), true, nil
+	}
+
+	p := filepath.FromSlash(ref.Path)
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(filepath.Dir(g.SpecLocation), p)
+	}
+	return p, false, nil
+}
+
+// loadExternalValue reads the content of an external value, once per location
+func (g *Generator) loadExternalValue(location string, isURL bool) ([]byte, error) {
+	if content, ok := g.externalValues[location]; ok {
+		return content, nil
+	}
+
+	var content []byte
+	if isURL {
+		resp, err := externalClient.Get(location)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		if content, err = io.ReadAll(resp.Body); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if content, err = os.ReadFile(location); err != nil {
+			return nil, err
+		}
+	}
+
+	if g.externalValues == nil {
+		g.externalValues = make(map[string][]byte)
+	}
+	g.externalValues[location] = content
+	return content, nil
+}
+
+// fileRef returns a reference to a local file, in the .http file syntax for sending a file,
+// relative to OutputDir when possible
+func (g *Generator) fileRef(file string) string {
+	dir := g.OutputDir
+	if dir == "" {
+		dir = "."
+	}
+
+	if absDir, err := filepath.Abs(dir); err == nil {
+		if absFile, err := filepath.Abs(file); err == nil {
+			if rel, err := filepath.Rel(absDir, absFile); err == nil {
+				file = rel
+			}
+		}
+	}
+
+	p := filepath.ToSlash(file)
+	if !path.IsAbs(p) && !strings.HasPrefix(p, "../") {
+		p = "./" + p
+	}
+	return "< " + p
+}
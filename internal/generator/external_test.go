@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/kalli/openapi-http/internal/parser"
+)
+
+// externalValueOperation is an operation with a request body example given by externalValue
+func externalValueOperation(contentType, externalValue string) parser.Operation {
+	pathItem := &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			OperationID: "addPet",
+			RequestBody: &openapi3.RequestBodyRef{Value: &openapi3.RequestBody{
+				Content: openapi3.Content{
+					contentType: &openapi3.MediaType{
+						Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}}},
+						Examples: openapi3.Examples{
+							"large": &openapi3.ExampleRef{Value: &openapi3.Example{ExternalValue: externalValue}},
+						},
+					},
+				},
+			}},
+		},
+	}
+	return parser.Operation{Path: "/pet", Method: "POST", Operation: pathItem.Post, PathItem: pathItem}
+}
+
+func TestBuildHTTPRequest_ExternalValue(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "examples"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "examples", "pet.json"), []byte("{\"name\": \"doggie\"}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "examples", "pet.bin"), []byte{0xff, 0xfe, 0x00}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		externalValue string
+		mode          ExternalValueMode
+		outputDir     string
+		expected      string
+	}{
+		{"inline", "examples/pet.json", "", "", `{"name": "doggie"}`},
+		{"inline relative to spec", "./examples/../examples/pet.json", ExternalInline, "", `{"name": "doggie"}`},
+		{"inline absolute", "file://" + filepath.ToSlash(filepath.Join(dir, "examples", "pet.json")), "", "", `{"name": "doggie"}`},
+		{"file", "examples/pet.json", ExternalFile, dir, "< ./examples/pet.json"},
+		{"file from output dir", "examples/pet.json", ExternalFile, filepath.Join(dir, "http"), "< ../examples/pet.json"},
+		{"binary", "examples/pet.bin", ExternalInline, dir, "< ./examples/pet.bin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewGenerator(&openapi3.T{})
+			gen.SpecLocation = filepath.Join(dir, "openapi.yaml")
+			gen.ExternalValues = tt.mode
+			gen.OutputDir = tt.outputDir
+
+			result, err := gen.BuildHTTPRequest(externalValueOperation("application/json", tt.externalValue))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.HasSuffix(result, "\n\n"+tt.expected+"\n") {
+				t.Errorf("expected body %q, got:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestBuildHTTPRequest_ExternalValueURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/specs/examples/pet.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("<Pet><name>doggie</name></Pet>"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		externalValue string
+		mode          ExternalValueMode
+	}{
+		{"relative to spec", "examples/pet.xml", ExternalInline},
+		{"absolute", server.URL + "/specs/examples/pet.xml", ExternalInline},
+		// URLs can't be referenced from a .http file
+		{"file", "examples/pet.xml", ExternalFile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewGenerator(&openapi3.T{})
+			gen.SpecLocation = server.URL + "/specs/openapi.yaml"
+			gen.ExternalValues = tt.mode
+
+			result, err := gen.BuildHTTPRequest(externalValueOperation("application/xml", tt.externalValue))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.HasSuffix(result, "\n\n<Pet><name>doggie</name></Pet>\n") {
+				t.Errorf("expected the external value as body, got:\n%s", result)
+			}
+		})
+	}
+
+	gen := NewGenerator(&openapi3.T{})
+	gen.SpecLocation = server.URL + "/specs/openapi.yaml"
+	if _, err := gen.BuildHTTPRequest(externalValueOperation("application/xml", "missing.xml")); err == nil {
+		t.Error("expected an error for a missing external value")
+	}
+}
+
+func TestBuildHTTPRequest_ExternalValueMissing(t *testing.T) {
+	gen := NewGenerator(&openapi3.T{})
+	gen.SpecLocation = filepath.Join(t.TempDir(), "openapi.yaml")
+
+	_, err := gen.BuildHTTPRequest(externalValueOperation("application/json", "missing.json"))
+	if err == nil || !strings.Contains(err.Error(), "externalValue missing.json") {
+		t.Errorf("expected an error for the missing external value, got %v", err)
+	}
+}
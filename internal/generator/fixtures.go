@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kalli/openapi-http/internal/parser"
+)
+
+// DefaultFixturesDir is where file references point to when FixturesDir is not set
+const DefaultFixturesDir = "fixtures"
+
+// nonWord matches the characters that don't belong in a file name
+var nonWord = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// fixtureRef returns a reference to a file in the fixtures directory, in the .http file
+// syntax for sending a file, and remembers it for CreateFixtures
+func (g *Generator) fixtureRef(name string) string {
+	dir := g.FixturesDir
+	if dir == "" {
+		dir = DefaultFixturesDir
+	}
+
+	p := path.Join(filepath.ToSlash(dir), name)
+	if !path.IsAbs(p) && !strings.HasPrefix(p, "../") {
+		p = "./" + p
+	}
+
+	if g.fixtures == nil {
+		g.fixtures = make(map[string]bool)
+	}
+	g.fixtures[p] = true
+
+	return "< " + p
+}
+
+// CreateFixtures creates empty placeholder files for the fixtures referenced by the requests
+// generated so far, so they can be sent as is. Relative paths are resolved against base, the
+// directory of the .http file. Existing files are left alone. It returns the created files.
+func (g *Generator) CreateFixtures(base string) ([]string, error) {
+	var created []string
+	for _, p := range slices.Sorted(maps.Keys(g.fixtures)) {
+		file := filepath.FromSlash(p)
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(base, file)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return created, err
+		}
+		f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return created, err
+		}
+		if err := f.Close(); err != nil {
+			return created, err
+		}
+		created = append(created, file)
+	}
+	return created, nil
+}
+
+// operationName names files for an operation: its operationId, or its method and path
+// when it has none, e.g. post_pet_petId
+func operationName(op parser.Operation) string {
+	if op.Operation.OperationID != "" {
+		return op.Operation.OperationID
+	}
+	return strings.Trim(nonWord.ReplaceAllString(strings.ToLower(op.Method)+"_"+op.Path, "_"), "_")
+}
@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/kalli/openapi-http/internal/parser"
+)
+
+func TestBuildHTTPRequest_BinaryBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		operationID string
+		contentType string
+		schema      *openapi3.Schema
+		fixturesDir string
+		expected    string
+	}{
+		{"octet-stream", "uploadFile", "application/octet-stream", nil, "", "< ./fixtures/uploadFile.bin"},
+		{"image", "uploadFile", "image/png", nil, "", "< ./fixtures/uploadFile.png"},
+		{"pdf", "uploadFile", "application/pdf", nil, "", "< ./fixtures/uploadFile.pdf"},
+		{"format binary", "uploadFile", "application/vnd.custom", &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "binary"}, "", "< ./fixtures/uploadFile.bin"},
+		{"no operation id", "", "application/octet-stream", nil, "", "< ./fixtures/post_pet_petId_uploadImage.bin"},
+		{"fixtures dir", "uploadFile", "application/octet-stream", nil, "testdata/files", "< ./testdata/files/uploadFile.bin"},
+		{"parent fixtures dir", "uploadFile", "application/octet-stream", nil, "../files", "< ../files/uploadFile.bin"},
+		{"absolute fixtures dir", "uploadFile", "application/octet-stream", nil, "/tmp/files", "< /tmp/files/uploadFile.bin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mediaType := &openapi3.MediaType{}
+			if tt.schema != nil {
+				mediaType.Schema = &openapi3.SchemaRef{Value: tt.schema}
+			}
+			pathItem := &openapi3.PathItem{
+				Post: &openapi3.Operation{
+					OperationID: tt.operationID,
+					RequestBody: &openapi3.RequestBodyRef{Value: &openapi3.RequestBody{
+						Content: openapi3.Content{tt.contentType: mediaType},
+					}},
+				},
+			}
+			op := parser.Operation{Path: "/pet/{petId}/uploadImage", Method: "POST", Operation: pathItem.Post, PathItem: pathItem}
+
+			gen := NewGenerator(&openapi3.T{})
+			gen.FixturesDir = tt.fixturesDir
+			result, err := gen.BuildHTTPRequest(op)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.HasSuffix(result, "\n\n"+tt.expected+"\n") {
+				t.Errorf("expected body %q, got:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCreateFixtures(t *testing.T) {
+	base := t.TempDir()
+
+	gen := NewGenerator(&openapi3.T{})
+	gen.fixtureRef("upload.bin")
+	gen.fixtureRef("photo.png")
+
+	// existing fixtures are kept
+	existing := filepath.Join(base, "fixtures", "photo.png")
+	if err := os.MkdirAll(filepath.Dir(existing), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("png"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := gen.CreateFixtures(base)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := filepath.Join(base, "fixtures", "upload.bin")
+	if len(created) != 1 || created[0] != expected {
+		t.Errorf("expected only %s to be created, got: %v", expected, created)
+	}
+	if _, err := os.Stat(expected); err != nil {
+		t.Errorf("expected fixture to exist: %v", err)
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "png" {
+		t.Errorf("expected existing fixture to be unchanged, got %q", data)
+	}
+}
@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// encodeForm serializes a request body as application/x-www-form-urlencoded, following
+// the style, explode and allowReserved of each property's encoding. Properties without
+// any are form style with explode, except objects, which are sent as JSON like their
+// default contentType says.
+func encodeForm(data any, encoding map[string]*openapi3.Encoding) string {
+	obj, ok := data.(map[string]any)
+	if !ok {
+		// e.g. an example that is already serialized
+		return scalarString(data)
+	}
+
+	var pairs []string
+	for _, name := range slices.Sorted(maps.Keys(obj)) {
+		value := obj[name]
+		enc := encoding[name]
+
+		sm := enc.SerializationMethod()
+		allowReserved := false
+		switch {
+		case enc != nil && (enc.Style != "" || enc.Explode != nil || enc.AllowReserved):
+			allowReserved = enc.AllowReserved
+		case enc != nil && isJSONMediaType(enc.ContentType):
+			value = scalarString(value)
+		default:
+			if _, isObject := value.(map[string]any); isObject {
+				value = scalarString(value)
+			}
+		}
+
+		pairs = append(pairs, serializeStyle(name, value, sm, allowReserved)...)
+	}
+
+	return strings.Join(pairs, "&")
+}
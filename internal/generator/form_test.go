@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestEncodeForm(t *testing.T) {
+	no := false
+	data := map[string]any{
+		"name":    "Jane Doe",
+		"tags":    []any{"a", "b"},
+		"address": map[string]any{"city": "London"},
+		"ids":     []any{1, 2},
+		"filter":  map[string]any{"status": "sold"},
+		"next":    "https://example.com/?page=2",
+	}
+
+	tests := []struct {
+		name     string
+		encoding map[string]*openapi3.Encoding
+		expected string
+	}{
+		{
+			name:     "defaults",
+			expected: "address=%7B%22city%22%3A%22London%22%7D&filter=%7B%22status%22%3A%22sold%22%7D&ids=1&ids=2&name=Jane%20Doe&next=https%3A%2F%2Fexample.com%2F%3Fpage%3D2&tags=a&tags=b",
+		},
+		{
+			name: "encoding",
+			encoding: map[string]*openapi3.Encoding{
+				"ids":    {Explode: &no},
+				"tags":   {Style: openapi3.SerializationPipeDelimited, Explode: &no},
+				"filter": {Style: openapi3.SerializationDeepObject},
+				"next":   {AllowReserved: true},
+			},
+			expected: "address=%7B%22city%22%3A%22London%22%7D&filter[status]=sold&ids=1,2&name=Jane%20Doe&next=https://example.com/?page=2&tags=a|b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := encodeForm(data, tt.encoding); result != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestEncodeForm_SerializedExample(t *testing.T) {
+	if result := encodeForm("name=rex&status=sold", nil); result != "name=rex&status=sold" {
+		t.Errorf("expected the example as is, got %q", result)
+	}
+}
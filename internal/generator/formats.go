@@ -0,0 +1,66 @@
+package generator
+
+// formatExamples are the built-in example values for string formats, covering the
+// OpenAPI and JSON Schema formats plus a few common extensions
+var formatExamples = map[string]string{
+	// dates and times
+	"date":      "2024-01-01",
+	"date-time": "2024-01-01T00:00:00Z",
+	"time":      "12:00:00Z",
+	"duration":  "P1DT2H",
+
+	// email and hostnames
+	"email":        "user@example.com",
+	"idn-email":    "user@example.com",
+	"hostname":     "api.example.com",
+	"idn-hostname": "api.example.com",
+	"ipv4":         "192.0.2.1",
+	"ipv6":         "2001:db8::1",
+
+	// resource identifiers
+	"uri":           "https://example.com/resource",
+	"uri-reference": "/resource",
+	"uri-template":  "https://example.com/resource/{id}",
+	"iri":           "https://example.com/ressource/é",
+	"iri-reference": "/ressource/é",
+	"url":           "https://example.com/resource",
+	"uuid":          "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+
+	// json pointers and regular expressions
+	"json-pointer":          "/path/to/property",
+	"relative-json-pointer": "0/property",
+	"regex":                 "^[a-z]+$",
+
+	// content
+	"byte":     "ZXhhbXBsZQ==",
+	"binary":   "binary",
+	"password": "P@ssw0rd!",
+
+	// common extensions
+	"phone":        "+15555550100",
+	"country-code": "US",
+	"currency":     "USD",
+	"language":     "en-US",
+}
+
+// RegisterFormat sets the example value used for strings of the given format, which
+// can be a custom format like x-tenant-id or override a built-in one
+func (g *Generator) RegisterFormat(format, example string) {
+	if g.formats == nil {
+		g.formats = make(map[string]string)
+	}
+	g.formats[format] = example
+
+	// examples generated before may use the format
+	clear(g.examples)
+}
+
+// formatExample looks up the example value for a string format, preferring formats
+// registered on the generator over the built-in ones
+func (g *Generator) formatExample(format string) (string, bool) {
+	if example, ok := g.formats[format]; ok {
+		return example, true
+	}
+	example, ok := formatExamples[format]
+	return example, ok
+}
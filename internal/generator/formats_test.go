@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"encoding/base64"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestFormatExamples_AreValid(t *testing.T) {
+	checks := map[string]func(string) error{
+		"date": func(s string) error {
+			_, err := time.Parse(time.DateOnly, s)
+			return err
+		},
+		"date-time": func(s string) error {
+			_, err := time.Parse(time.RFC3339, s)
+			return err
+		},
+		"byte": func(s string) error {
+			_, err := base64.StdEncoding.DecodeString(s)
+			return err
+		},
+		"uri": func(s string) error {
+			_, err := url.ParseRequestURI(s)
+			return err
+		},
+		"ipv4": func(s string) error {
+			if ip := net.ParseIP(s); ip == nil || ip.To4() == nil {
+				return &net.ParseError{Type: "IPv4 address", Text: s}
+			}
+			return nil
+		},
+		"ipv6": func(s string) error {
+			if ip := net.ParseIP(s); ip == nil || ip.To4() != nil {
+				return &net.ParseError{Type: "IPv6 address", Text: s}
+			}
+			return nil
+		},
+	}
+
+	for format, check := range checks {
+		t.Run(format, func(t *testing.T) {
+			if err := check(formatExamples[format]); err != nil {
+				t.Errorf("invalid example for %s: %v", format, err)
+			}
+		})
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	gen := &Generator{}
+	gen.RegisterFormat("x-tenant-id", "tenant-123")
+	gen.RegisterFormat("email", "someone@example.org")
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"x-tenant-id", "tenant-123"},
+		{"email", "someone@example.org"},
+		{"uuid", formatExamples["uuid"]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			schema := &openapi3.Schema{
+				Type:   &openapi3.Types{"string"},
+				Format: tt.format,
+			}
+
+			result := gen.generateExample(schema)
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got: %v", tt.expected, result)
+			}
+		})
+	}
+}
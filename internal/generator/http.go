@@ -1,9 +1,10 @@
 package generator
 
 import (
-	"encoding/json"
 	"fmt"
 	"maps"
+	"math/rand/v2"
+	"slices"
 	"strings"
 
 	"github.com/kalli/openapi-http/internal/parser"
@@ -11,8 +12,99 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
+// BodyMode controls how much of a request body is generated
+type BodyMode string
+
+const (
+	BodyFull     BodyMode = "full"     // all properties
+	BodyRequired BodyMode = "required" // only required properties, recursively
+	BodyNone     BodyMode = "none"     // a placeholder instead of a body
+)
+
 type Generator struct {
 	spec *openapi3.T
+
+	// Variant selects which oneOf/anyOf branch to generate examples for, by schema
+	// name, title or discriminator value. The first branch is used when empty.
+	Variant string
+
+	// MaxDepth limits how deeply nested schemas are generated, DefaultMaxDepth when zero.
+	MaxDepth int
+
+	// ArrayItems is how many items arrays, and entries maps, get by default, one when
+	// zero. minItems, maxItems, minProperties and maxProperties take precedence.
+	ArrayItems int
+
+	// Body controls how much of the request body is generated, BodyFull when empty.
+	Body BodyMode
+
+	// ContentType is the media type to send request bodies as when an operation offers
+	// it, application/json is preferred when empty. It can be a pattern like application/*
+	// or */*+xml, see RegisterSerializer.
+	ContentType string
+
+	// EachContentType generates a request for each media type a request body can be sent
+	// as, instead of only the preferred one.
+	EachContentType bool
+
+	// Example selects the named example to use for the request body and parameters. When
+	// empty a request is generated for each named example.
+	Example string
+
+	// FixturesDir is the directory binary request bodies and file parts are read from,
+	// relative to the .http file, DefaultFixturesDir when empty.
+	FixturesDir string
+
+	// ExternalValues controls how examples given by externalValue are included in request
+	// bodies, ExternalInline when empty.
+	ExternalValues ExternalValueMode
+
+	// SpecLocation is the path or URL the spec was loaded from, externalValue is resolved
+	// against it.
+	SpecLocation string
+
+	// OutputDir is the directory of the .http file, files are referenced relative to it.
+	// The working directory when empty.
+	OutputDir string
+
+	// Realistic fills in string and number properties with realistic values based on their
+	// name, like a city for billingCity, as long as they fit the schema.
+	Realistic bool
+
+	// example values for custom string formats, see RegisterFormat
+	formats map[string]string
+
+	// serializers for request bodies, see RegisterSerializer
+	serializers []serializerEntry
+
+	// examples generated so far, see generateFor
+	examples map[exampleKey]interface{}
+
+	// fixture files referenced so far, see CreateFixtures
+	fixtures map[string]bool
+
+	// content of the external values loaded so far, by location
+	externalValues map[string][]byte
+
+	// random source when seeded, see SetSeed
+	seed   uint64
+	seeded bool
+	rand   *rand.Rand
+
+	// media type and named example of the request being built
+	contentType string
+	example     string
+
+	// state of the example currently being generated
+	depth    int
+	visiting map[*openapi3.Schema]bool
+	property string       // name of the property being generated, if any
+	usage    exampleUsage // what the example is for
+
+	// requiredOnly leaves out optional properties, inheritedRequired holds the required
+	// properties of the allOf being generated
+	requiredOnly      bool
+	inheritedRequired []string
 }
 
 func NewGenerator(spec *openapi3.T) *Generator {
@@ -22,20 +114,60 @@ func NewGenerator(spec *openapi3.T) *Generator {
 // generates an HTTP request in rfc9110 compliant .http file format from an OpenAPI operation,
 // including method, URL, headers, and body.
 // Adds @name attributes based on the operationID
+// Operations with named examples get a request for each of them, unless Example selects
+// one, and with EachContentType set a request for each media type of the request body.
+// Their names get a suffix for the media type and example, e.g. addPet_xml_minimal.
 func (g *Generator) BuildHTTPRequest(op parser.Operation) (string, error) {
+	defer func() { g.contentType, g.example = "", "" }()
+
+	var requests []string
+	for _, contentType := range g.contentTypeVariants(op) {
+		g.contentType = contentType.contentType
+
+		examples, err := g.exampleVariants(op)
+		if err != nil {
+			return "", err
+		}
+		for _, example := range examples {
+			g.example = example.name
+
+			name := op.Operation.OperationID
+			if name != "" {
+				name = strings.Join(slices.DeleteFunc([]string{name, contentType.suffix, example.suffix}, func(s string) bool { return s == "" }), "_")
+			}
+			req, err := g.buildRequest(op, name, example.summary)
+			if err != nil {
+				return "", err
+			}
+			requests = append(requests, req)
+		}
+	}
+
+	return strings.Join(requests, "\n"), nil
+}
+
+// buildRequest generates a single request for an operation, with name as its @name and
+// the summary of its example as a comment
+func (g *Generator) buildRequest(op parser.Operation, name, exampleSummary string) (string, error) {
 	var sb strings.Builder
 
 	sb.WriteString("###\n")
 
+	// seeded values only depend on the operation itself
+	g.reseed(op.Method + " " + op.Path)
+
 	// add @name if operationId exists
-	if op.Operation.OperationID != "" {
-		sb.WriteString(fmt.Sprintf("# @name %s\n", op.Operation.OperationID))
+	if name != "" {
+		sb.WriteString(fmt.Sprintf("# @name %s\n", name))
 	}
 
 	// add summary as comment if present
 	if op.Operation.Summary != "" {
 		sb.WriteString(fmt.Sprintf("# %s\n", op.Operation.Summary))
 	}
+	if exampleSummary != "" {
+		sb.WriteString(fmt.Sprintf("# %s\n", exampleSummary))
+	}
 
 	sb.WriteString("\n")
 
@@ -52,8 +184,8 @@ func (g *Generator) BuildHTTPRequest(op parser.Operation) (string, error) {
 
 	// headers
 	headers := g.buildHeaders(op)
-	for k, v := range headers {
-		sb.WriteString(fmt.Sprintf("%s: %s\n", k, v))
+	for _, k := range slices.Sorted(maps.Keys(headers)) {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", k, headers[k]))
 	}
 
 	// request body
@@ -90,12 +222,20 @@ func (g *Generator) buildPath(op parser.Operation) string {
 		placeholder := fmt.Sprintf("{{%s}}", param.Name)
 
 		// try to get example value
-		if param.Example != nil {
-			placeholder = fmt.Sprintf("%v", param.Example)
+		var value any
+		if example, ok := g.parameterExample(param); ok {
+			value = example
 		} else if param.Schema != nil && param.Schema.Value != nil {
-			if example := g.generateExample(param.Schema.Value); example != nil {
-				placeholder = fmt.Sprintf("%v", example)
+			value = g.generateRequestExample(param.Schema)
+		}
+
+		// serialized following the style and explode of the parameter, simple by default
+		if value != nil {
+			sm, err := param.SerializationMethod()
+			if err != nil {
+				sm = &openapi3.SerializationMethod{Style: openapi3.SerializationSimple}
 			}
+			placeholder = serializePathStyle(param.Name, value, sm)
 		}
 
 		path = strings.ReplaceAll(path, fmt.Sprintf("{%s}", param.Name), placeholder)
@@ -113,48 +253,41 @@ func (g *Generator) buildQueryString(op parser.Operation) string {
 
 	var parts []string
 	for _, param := range params {
-		value := "{{" + param.Name + "}}"
-
-		if param.Example != nil {
-			value = g.formatParameterValue(param.Example)
+		var value any
+		if example, ok := g.parameterExample(param); ok {
+			value = example
 		} else if param.Schema != nil && param.Schema.Value != nil {
-			if example := g.generateExample(param.Schema.Value); example != nil {
-				value = g.formatParameterValue(example)
-			}
+			value = g.generateRequestExample(param.Schema)
+		}
+
+		if value == nil {
+			parts = append(parts, escapeValue(param.Name, false)+"={{"+param.Name+"}}")
+			continue
 		}
 
-		parts = append(parts, fmt.Sprintf("%s=%s", param.Name, value))
+		// serialized following the style and explode of the parameter, form with explode
+		// by default, e.g. status=available&status=pending or filter[color]=red for deepObject
+		sm, err := param.SerializationMethod()
+		if err != nil {
+			sm = &openapi3.SerializationMethod{Style: openapi3.SerializationForm, Explode: true}
+		}
+		parts = append(parts, serializeStyle(param.Name, value, sm, param.AllowReserved)...)
 	}
 
 	return strings.Join(parts, "&")
 }
 
-
-// formatParameterValue formats a parameter value for use in URLs
-// If the value is an array/slice, extracts the first element
-func (g *Generator) formatParameterValue(value any) string {
-	// handle array/slice values by extracting first element
-	if slice, ok := value.([]any); ok && len(slice) > 0 {
-		return fmt.Sprintf("%v", slice[0])
-	}
-	return fmt.Sprintf("%v", value)
-}
-
 // builds headers defined for the request
 func (g *Generator) buildHeaders(op parser.Operation) map[string]string {
 	headers := make(map[string]string)
 
 	// content-type from request body
 	if op.Operation.RequestBody != nil && op.Operation.RequestBody.Value != nil {
-
-		// if request body has json, prefer that. Seems likely to be most common use case.
-		if _, ok := op.Operation.RequestBody.Value.Content["application/json"]; ok {
-			headers["Content-Type"] = "application/json"
-		} else {
-			for contentType := range op.Operation.RequestBody.Value.Content {
-				headers["Content-Type"] = contentType
-				break
+		if contentType, _ := g.requestMediaType(op.Operation.RequestBody.Value); contentType != "" {
+			if _, multipart := matchMediaType("multipart/*", contentType); multipart && !strings.Contains(contentType, "boundary=") {
+				contentType += "; boundary=" + multipartBoundary
 			}
+			headers["Content-Type"] = contentType
 		}
 	}
 
@@ -162,18 +295,53 @@ func (g *Generator) buildHeaders(op parser.Operation) map[string]string {
 	params := g.collectParameters(op, "header")
 	for _, param := range params {
 		value := "{{" + param.Name + "}}"
-		if param.Example != nil {
-			value = fmt.Sprintf("%v", param.Example)
+		if example, ok := g.parameterExample(param); ok {
+			value = fmt.Sprintf("%v", example)
 		}
 		headers[param.Name] = value
 	}
 
-	// security headers
+	// security headers, apiKey cookies are sent along with the cookie params
+	cookies := g.buildCookies(op)
 	securityHeaders := g.buildSecurityHeaders(op)
+	if cookie, ok := securityHeaders["Cookie"]; ok {
+		cookies = append(cookies, cookie)
+		delete(securityHeaders, "Cookie")
+	}
 	maps.Copy(headers, securityHeaders)
+
+	if len(cookies) > 0 {
+		headers["Cookie"] = strings.Join(cookies, "; ")
+	}
 	return headers
 }
 
+// buildCookies returns the name=value pairs of the cookie params for the Cookie header
+func (g *Generator) buildCookies(op parser.Operation) []string {
+	var cookies []string
+	for _, param := range g.collectParameters(op, "cookie") {
+		var value any
+		if example, ok := g.parameterExample(param); ok {
+			value = example
+		} else if param.Schema != nil && param.Schema.Value != nil {
+			value = g.generateRequestExample(param.Schema)
+		}
+
+		if value == nil {
+			cookies = append(cookies, param.Name+"={{"+param.Name+"}}")
+			continue
+		}
+
+		// serialized like query params, form with explode by default
+		sm, err := param.SerializationMethod()
+		if err != nil {
+			sm = &openapi3.SerializationMethod{Style: openapi3.SerializationForm, Explode: true}
+		}
+		cookies = append(cookies, serializeStyle(param.Name, value, sm, false)...)
+	}
+	return cookies
+}
+
 // buildSecurityHeaders generates authentication headers based on security requirements
 func (g *Generator) buildSecurityHeaders(op parser.Operation) map[string]string {
 	headers := make(map[string]string)
@@ -198,8 +366,9 @@ func (g *Generator) buildSecurityHeaders(op parser.Operation) map[string]string
 	}
 
 	// process first security requirement (usually there's only one)
-	// if there are multiple, they represent alternatives (OR), not combinations
-	for schemeName := range securityReqs[0] {
+	// if there are multiple, they represent alternatives (OR), not combinations,
+	// while the schemes of a single requirement are all needed (AND)
+	for _, schemeName := range slices.Sorted(maps.Keys(securityReqs[0])) {
 		schemeRef := g.spec.Components.SecuritySchemes[schemeName]
 		if schemeRef == nil || schemeRef.Value == nil {
 			continue
@@ -210,10 +379,18 @@ func (g *Generator) buildSecurityHeaders(op parser.Operation) map[string]string
 		switch scheme.Type {
 		case "apiKey":
 			// API key in header, query, or cookie
-			if scheme.In == "header" {
+			switch scheme.In {
+			case "header":
 				headers[scheme.Name] = fmt.Sprintf("{{%s}}", scheme.Name)
+			case "cookie":
+				// merged with the cookie params by buildHeaders
+				cookie := fmt.Sprintf("%s={{%s}}", scheme.Name, scheme.Name)
+				if existing, ok := headers["Cookie"]; ok {
+					cookie = existing + "; " + cookie
+				}
+				headers["Cookie"] = cookie
 			}
-			// Note: query and cookie params are handled elsewhere
+			// Note: query params are handled elsewhere
 
 		case "http":
 			// HTTP authentication (Basic, Bearer, etc.)
@@ -234,67 +411,87 @@ func (g *Generator) buildSecurityHeaders(op parser.Operation) map[string]string
 			// mutual TLS is handled at connection level, not in headers
 			// nothing to add here
 		}
-
-		// only process first security scheme
-		break
 	}
 
 	return headers
 }
 
-// builds a request body based on the operation schema
-// todo: Currently limited to json requests, add support for other types
+// builds a request body based on the operation schema, serialized for its media type,
+// see RegisterSerializer
 func (g *Generator) buildRequestBody(op parser.Operation) (string, error) {
 	if op.Operation.RequestBody == nil || op.Operation.RequestBody.Value == nil {
 		return "", nil
 	}
 
-	rb := op.Operation.RequestBody.Value
+	contentType, mediaType := g.requestMediaType(op.Operation.RequestBody.Value)
+	if mediaType == nil {
+		return "", nil
+	}
 
-	// prefer application/json
-	var mediaType *openapi3.MediaType
-	if mt, ok := rb.Content["application/json"]; ok {
-		mediaType = mt
-	} else {
-		// fallback to first available
-		for _, mt := range rb.Content {
-			mediaType = mt
-			break
-		}
+	if g.Body == BodyNone {
+		return "{{body}}", nil
 	}
 
-	if mediaType == nil {
-		return "", nil
+	// files are sent as they are, whatever their media type
+	if mediaType.Schema != nil && isBinarySchema(mediaType.Schema.Value) {
+		return g.fixtureRef(fileName(operationName(op), contentType)), nil
 	}
 
+	// a minimal body is generated from the schema, explicit examples are usually complete
+	minimal := g.Body == BodyRequired && mediaType.Schema != nil && mediaType.Schema.Value != nil
+	g.requiredOnly = minimal
+	defer func() { g.requiredOnly = false }()
+
 	// try to get example
 	var data interface{}
-	if mediaType.Example != nil {
+	if minimal {
+		data = g.generateRequestExample(mediaType.Schema)
+	} else if mediaType.Example != nil {
 		data = mediaType.Example
-	} else if mediaType.Examples != nil && len(mediaType.Examples) > 0 {
-		// use first example
-		for _, ex := range mediaType.Examples {
-			if ex.Value != nil {
-				data = ex.Value.Value
-				break
-			}
-		}
+	} else if ex := g.namedExample(mediaType.Examples); ex != nil && ex.Value == nil && ex.ExternalValue != "" {
+		// external values are already serialized
+		return g.externalBody(ex.ExternalValue)
+	} else if ex != nil {
+		// the example of the request being generated
+		data = ex.Value
 	} else if mediaType.Schema != nil && mediaType.Schema.Value != nil {
 		// generate from schema
-		data = g.generateExample(mediaType.Schema.Value)
+		data = g.generateRequestExample(mediaType.Schema)
 	}
 
-	if data == nil {
-		return "{}", nil
+	serialize := g.serializerFor(contentType)
+	return serialize(Body{Operation: op, ContentType: contentType, MediaType: mediaType, Example: data})
+}
+
+// requestMediaType picks the media type to send a request body as: the first one matching
+// ContentType, JSON if the body has it as that seems likely to be the most common use case,
+// otherwise the first one in alphabetical order
+func (g *Generator) requestMediaType(rb *openapi3.RequestBody) (string, *openapi3.MediaType) {
+	if mt, ok := rb.Content[g.contentType]; ok && g.contentType != "" {
+		return g.contentType, mt
 	}
 
-	// format as json
-	jsonBytes, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return "", err
+	contentTypes := slices.Sorted(maps.Keys(rb.Content))
+	if g.ContentType != "" {
+		for _, contentType := range contentTypes {
+			if _, ok := matchMediaType(g.ContentType, contentType); ok {
+				return contentType, rb.Content[contentType]
+			}
+		}
 	}
 
-	return string(jsonBytes), nil
+	if mt, ok := rb.Content["application/json"]; ok {
+		return "application/json", mt
+	}
+	for _, contentType := range contentTypes {
+		if isJSONMediaType(contentType) {
+			return contentType, rb.Content[contentType]
+		}
+	}
+	if len(contentTypes) > 0 {
+		return contentTypes[0], rb.Content[contentTypes[0]]
+	}
+	return "", nil
 }
 
 // collects all parameters for an operation, either path or op level
@@ -265,6 +265,70 @@ func TestBuildHTTPRequest_WithJSONBody(t *testing.T) {
 	}
 }
 
+func TestBuildHTTPRequest_WithFormBody(t *testing.T) {
+	spec := &openapi3.T{
+		Servers: []*openapi3.Server{
+			{URL: "https://api.example.com"},
+		},
+	}
+
+	schema := openapi3.NewSchema()
+	schema.Type = &openapi3.Types{"object"}
+	schema.Properties = openapi3.Schemas{
+		"name": &openapi3.SchemaRef{
+			Value: &openapi3.Schema{
+				Type:    &openapi3.Types{"string"},
+				Example: "John Doe",
+			},
+		},
+		"age": &openapi3.SchemaRef{
+			Value: &openapi3.Schema{
+				Type:    &openapi3.Types{"integer"},
+				Example: 30,
+			},
+		},
+	}
+
+	pathItem := &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			OperationID: "createUser",
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: &openapi3.RequestBody{
+					Content: openapi3.Content{
+						"application/x-www-form-urlencoded": &openapi3.MediaType{
+							Schema: &openapi3.SchemaRef{
+								Value: schema,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	op := parser.Operation{
+		Path:      "/users",
+		Method:    "POST",
+		Operation: pathItem.Post,
+		PathItem:  pathItem,
+	}
+
+	gen := NewGenerator(spec)
+	result, err := gen.BuildHTTPRequest(op)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "Content-Type: application/x-www-form-urlencoded") {
+		t.Error("expected Content-Type header")
+	}
+
+	if !strings.Contains(result, "\nage=30&name=John%20Doe\n") {
+		t.Errorf("expected form encoded body, got:\n%s", result)
+	}
+}
+
 func TestBuildHTTPRequest_NoOperationID(t *testing.T) {
 	spec := &openapi3.T{
 		Servers: []*openapi3.Server{
@@ -373,6 +437,76 @@ func TestBuildPath_WithMultipleParameters(t *testing.T) {
 	}
 }
 
+func TestBuildPath_Styles(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    string
+		explode  *bool
+		expected string
+	}{
+		{"simple", "", nil, "/pets/1,2"},
+		{"label", "label", openapi3.BoolPtr(true), "/pets/.1.2"},
+		{"matrix", "matrix", nil, "/pets/;id=1,2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pathItem := &openapi3.PathItem{
+				Get: &openapi3.Operation{
+					Parameters: openapi3.Parameters{
+						&openapi3.ParameterRef{Value: &openapi3.Parameter{
+							Name:    "id",
+							In:      "path",
+							Style:   tt.style,
+							Explode: tt.explode,
+							Example: []any{1, 2},
+						}},
+					},
+				},
+			}
+			op := parser.Operation{Path: "/pets/{id}", Method: "GET", Operation: pathItem.Get, PathItem: pathItem}
+
+			gen := NewGenerator(&openapi3.T{})
+			if path := gen.buildPath(op); path != tt.expected {
+				t.Errorf("expected path %s, got: %s", tt.expected, path)
+			}
+		})
+	}
+}
+
+func TestBuildHTTPRequest_EscapesURL(t *testing.T) {
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{Name: "name", In: "path", Example: "Mr. Fluffy/2"}},
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{Name: "q", In: "query", Example: "a b&c"}},
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{Name: "city", In: "query", Example: "Reykjavík"}},
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{Name: "next", In: "query", Example: "/pets?page=2", AllowReserved: true}},
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{Name: "token", In: "query", Example: "{{token}}"}},
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{Name: "limit", In: "query"}},
+			},
+		},
+	}
+
+	op := parser.Operation{
+		Path:      "/pets/{name}",
+		Method:    "GET",
+		Operation: pathItem.Get,
+		PathItem:  pathItem,
+	}
+
+	gen := NewGenerator(&openapi3.T{})
+	result, err := gen.BuildHTTPRequest(op)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "GET {{hostname}}/pets/Mr.%20Fluffy%2F2?q=a%20b%26c&city=Reykjav%C3%ADk&next=/pets?page=2&token={{token}}&limit={{limit}}\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected request line %q, got:\n%s", expected, result)
+	}
+}
+
 func TestBuildQueryString_NoParameters(t *testing.T) {
 	spec := &openapi3.T{}
 
@@ -456,6 +590,368 @@ func TestBuildHeaders_PreferJSON(t *testing.T) {
 	}
 }
 
+func TestBuildHTTPRequest_PreferredContentType(t *testing.T) {
+	schema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Pet",
+		Value: &openapi3.Schema{
+			Type: &openapi3.Types{"object"},
+			Properties: openapi3.Schemas{
+				"name": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Example: "doggie"}},
+			},
+		},
+	}
+
+	pathItem := &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: &openapi3.RequestBody{
+					Content: openapi3.Content{
+						"application/xml":  &openapi3.MediaType{Schema: schema},
+						"application/json": &openapi3.MediaType{Schema: schema},
+					},
+				},
+			},
+		},
+	}
+
+	op := parser.Operation{
+		Path:      "/pet",
+		Method:    "POST",
+		Operation: pathItem.Post,
+		PathItem:  pathItem,
+	}
+
+	gen := NewGenerator(&openapi3.T{})
+	gen.ContentType = "application/xml"
+	result, err := gen.BuildHTTPRequest(op)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "Content-Type: application/xml") {
+		t.Error("expected the preferred Content-Type header")
+	}
+	if !strings.Contains(result, "<Pet>\n  <name>doggie</name>\n</Pet>") {
+		t.Errorf("expected an XML body, got:\n%s", result)
+	}
+
+	// not offered, so JSON is used
+	gen.ContentType = "text/csv"
+	result, err = gen.BuildHTTPRequest(op)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(result, "Content-Type: application/json") {
+		t.Error("expected to fall back to application/json")
+	}
+}
+
+func TestBuildHeaders_PreferStructuredJSON(t *testing.T) {
+	spec := &openapi3.T{}
+
+	pathItem := &openapi3.PathItem{
+		Patch: &openapi3.Operation{
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: &openapi3.RequestBody{
+					Content: openapi3.Content{
+						"application/xml":              &openapi3.MediaType{},
+						"application/merge-patch+json": &openapi3.MediaType{},
+					},
+				},
+			},
+		},
+	}
+
+	op := parser.Operation{
+		Path:      "/test",
+		Method:    "PATCH",
+		Operation: pathItem.Patch,
+		PathItem:  pathItem,
+	}
+
+	gen := NewGenerator(spec)
+	headers := gen.buildHeaders(op)
+
+	if headers["Content-Type"] != "application/merge-patch+json" {
+		t.Errorf("expected to prefer a +json media type, got: %s", headers["Content-Type"])
+	}
+}
+
+func TestBuildHTTPRequest_ContentTypePattern(t *testing.T) {
+	pathItem := &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: &openapi3.RequestBody{
+					Content: openapi3.Content{
+						"application/json":     &openapi3.MediaType{},
+						"application/atom+xml": &openapi3.MediaType{},
+						"text/plain":           &openapi3.MediaType{},
+					},
+				},
+			},
+		},
+	}
+
+	op := parser.Operation{
+		Path:      "/pet",
+		Method:    "POST",
+		Operation: pathItem.Post,
+		PathItem:  pathItem,
+	}
+
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{"", "application/json"},
+		{"*/*+xml", "application/atom+xml"},
+		{"text/*", "text/plain"},
+		{"image/*", "application/json"},
+	}
+
+	for _, tt := range tests {
+		gen := NewGenerator(&openapi3.T{})
+		gen.ContentType = tt.pattern
+
+		if contentType := gen.buildHeaders(op)["Content-Type"]; contentType != tt.expected {
+			t.Errorf("%q: expected %s, got %s", tt.pattern, tt.expected, contentType)
+		}
+	}
+}
+
+func TestBuildHTTPRequest_EachContentType(t *testing.T) {
+	schema := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"name": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Example: "doggie"}},
+		},
+	}}
+
+	pathItem := &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			OperationID: "addPet",
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: &openapi3.RequestBody{
+					Content: openapi3.Content{
+						"application/json":                  &openapi3.MediaType{Schema: schema},
+						"application/xml":                   &openapi3.MediaType{Schema: schema},
+						"text/xml":                          &openapi3.MediaType{Schema: schema},
+						"application/x-www-form-urlencoded": &openapi3.MediaType{Schema: schema},
+					},
+				},
+			},
+		},
+	}
+
+	op := parser.Operation{
+		Path:      "/pet",
+		Method:    "POST",
+		Operation: pathItem.Post,
+		PathItem:  pathItem,
+	}
+
+	gen := NewGenerator(&openapi3.T{})
+	gen.EachContentType = true
+	result, err := gen.BuildHTTPRequest(op)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	requests := strings.Split(result, "###\n")[1:]
+	expected := []struct{ name, contentType, body string }{
+		{"addPet_json", "application/json", `"name": "doggie"`},
+		{"addPet_form", "application/x-www-form-urlencoded", "name=doggie"},
+		{"addPet_application_xml", "application/xml", "<name>doggie</name>"},
+		{"addPet_text_xml", "text/xml", "<name>doggie</name>"},
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("expected %d requests, got:\n%s", len(expected), result)
+	}
+	for i, req := range requests {
+		if !strings.Contains(req, "# @name "+expected[i].name+"\n") {
+			t.Errorf("request %d: expected name %s, got:\n%s", i, expected[i].name, req)
+		}
+		if !strings.Contains(req, "Content-Type: "+expected[i].contentType+"\n") {
+			t.Errorf("request %d: expected Content-Type %s, got:\n%s", i, expected[i].contentType, req)
+		}
+		if !strings.Contains(req, expected[i].body) {
+			t.Errorf("request %d: expected body with %s, got:\n%s", i, expected[i].body, req)
+		}
+	}
+
+	// the preferred media type is unaffected
+	gen.EachContentType = false
+	if result, _ := gen.BuildHTTPRequest(op); !strings.Contains(result, "# @name addPet\n") || !strings.Contains(result, "Content-Type: application/json") {
+		t.Errorf("expected a single JSON request, got:\n%s", result)
+	}
+}
+
+func TestBuildHTTPRequest_NamedExamples(t *testing.T) {
+	pathItem := &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			OperationID: "addPet",
+			Parameters: openapi3.Parameters{
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{
+					Name:   "X-Request-ID",
+					In:     "header",
+					Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+					Examples: openapi3.Examples{
+						"cat": &openapi3.ExampleRef{Value: &openapi3.Example{Value: "cat-request"}},
+						"dog": &openapi3.ExampleRef{Value: &openapi3.Example{Value: "dog-request"}},
+					},
+				}},
+			},
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: &openapi3.RequestBody{
+					Content: openapi3.Content{
+						"application/json": &openapi3.MediaType{
+							Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}}},
+							Examples: openapi3.Examples{
+								"dog": &openapi3.ExampleRef{Value: &openapi3.Example{
+									Summary: "A good boy",
+									Value:   map[string]any{"name": "doggie"},
+								}},
+								"cat": &openapi3.ExampleRef{Value: &openapi3.Example{
+									Summary: "A cat",
+									Value:   map[string]any{"name": "kitty"},
+								}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	op := parser.Operation{
+		Path:      "/pet",
+		Method:    "POST",
+		Operation: pathItem.Post,
+		PathItem:  pathItem,
+	}
+
+	gen := NewGenerator(&openapi3.T{})
+	result, err := gen.BuildHTTPRequest(op)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	requests := strings.Split(result, "###\n")[1:]
+	expected := []struct{ name, summary, header, body string }{
+		{"addPet_cat", "A cat", "cat-request", `"name": "kitty"`},
+		{"addPet_dog", "A good boy", "dog-request", `"name": "doggie"`},
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("expected %d requests, got:\n%s", len(expected), result)
+	}
+	for i, req := range requests {
+		if !strings.Contains(req, "# @name "+expected[i].name+"\n# "+expected[i].summary+"\n") {
+			t.Errorf("request %d: expected name %s and summary %q, got:\n%s", i, expected[i].name, expected[i].summary, req)
+		}
+		if !strings.Contains(req, "X-Request-ID: "+expected[i].header+"\n") {
+			t.Errorf("request %d: expected header %s, got:\n%s", i, expected[i].header, req)
+		}
+		if !strings.Contains(req, expected[i].body) {
+			t.Errorf("request %d: expected body with %s, got:\n%s", i, expected[i].body, req)
+		}
+	}
+
+	// a selected example gives a single request, without a suffix
+	gen.Example = "dog"
+	result, err = gen.BuildHTTPRequest(op)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Count(result, "###") != 1 {
+		t.Fatalf("expected a single request, got:\n%s", result)
+	}
+	for _, want := range []string{"# @name addPet\n", "# A good boy\n", "X-Request-ID: dog-request\n", `"name": "doggie"`} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q, got:\n%s", want, result)
+		}
+	}
+
+	// an example the operation doesn't define is reported instead of replaced by another
+	gen.Example = "typo"
+	_, err = gen.BuildHTTPRequest(op)
+	if err == nil || !strings.Contains(err.Error(), `example "typo" not found, expected one of cat, dog`) {
+		t.Errorf("expected an error for an unknown example, got: %v", err)
+	}
+}
+
+func TestBuildHTTPRequest_SingleNamedExample(t *testing.T) {
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			OperationID: "findPets",
+			Parameters: openapi3.Parameters{
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{
+					Name:   "status",
+					In:     "query",
+					Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+					Examples: openapi3.Examples{
+						"sold": &openapi3.ExampleRef{Value: &openapi3.Example{Summary: "Sold pets", Value: "sold"}},
+					},
+				}},
+			},
+		},
+	}
+
+	op := parser.Operation{
+		Path:      "/pet",
+		Method:    "GET",
+		Operation: pathItem.Get,
+		PathItem:  pathItem,
+	}
+
+	gen := NewGenerator(&openapi3.T{})
+	result, err := gen.BuildHTTPRequest(op)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"# @name findPets\n", "# Sold pets\n", "status=sold"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q, got:\n%s", want, result)
+		}
+	}
+
+	// operations without named examples ignore the selection, e.g. with --all
+	op.Operation.Parameters[0].Value.Examples = nil
+	gen.Example = "sold"
+	if _, err := gen.BuildHTTPRequest(op); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestBuildHeaders_MultipartBoundary(t *testing.T) {
+	spec := &openapi3.T{}
+
+	pathItem := &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: &openapi3.RequestBody{
+					Content: openapi3.Content{
+						"multipart/form-data": &openapi3.MediaType{},
+					},
+				},
+			},
+		},
+	}
+
+	op := parser.Operation{
+		Path:      "/test",
+		Method:    "POST",
+		Operation: pathItem.Post,
+		PathItem:  pathItem,
+	}
+
+	gen := NewGenerator(spec)
+	headers := gen.buildHeaders(op)
+
+	if headers["Content-Type"] != "multipart/form-data; boundary=WebAppBoundary" {
+		t.Errorf("expected multipart content type with boundary, got: %s", headers["Content-Type"])
+	}
+}
+
 func TestBuildRequestBody_WithExample(t *testing.T) {
 	spec := &openapi3.T{}
 
@@ -711,6 +1207,106 @@ func TestBuildSecurityHeaders_APIKeyInHeader(t *testing.T) {
 	}
 }
 
+func TestBuildHeaders_Cookies(t *testing.T) {
+	spec := &openapi3.T{
+		Components: &openapi3.Components{
+			SecuritySchemes: openapi3.SecuritySchemes{
+				"session": &openapi3.SecuritySchemeRef{
+					Value: &openapi3.SecurityScheme{
+						Type: "apiKey",
+						In:   "cookie",
+						Name: "SESSIONID",
+					},
+				},
+			},
+		},
+	}
+
+	pathItem := &openapi3.PathItem{
+		Parameters: openapi3.Parameters{
+			&openapi3.ParameterRef{Value: &openapi3.Parameter{Name: "tracking", In: "cookie"}},
+		},
+		Get: &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{Name: "theme", In: "cookie", Example: "dark mode"}},
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{
+					Name:   "lang",
+					In:     "cookie",
+					Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Example: "en"}},
+				}},
+			},
+			Security: &openapi3.SecurityRequirements{
+				{"session": []string{}},
+			},
+		},
+	}
+
+	op := parser.Operation{
+		Path:      "/secure",
+		Method:    "GET",
+		Operation: pathItem.Get,
+		PathItem:  pathItem,
+	}
+
+	gen := NewGenerator(spec)
+	headers := gen.buildHeaders(op)
+
+	expected := "tracking={{tracking}}; theme=dark%20mode; lang=en; SESSIONID={{SESSIONID}}"
+	if headers["Cookie"] != expected {
+		t.Errorf("expected Cookie %q, got: %q", expected, headers["Cookie"])
+	}
+
+	// without any cookies there is no Cookie header
+	op.Operation.Parameters = nil
+	op.Operation.Security = &openapi3.SecurityRequirements{}
+	op.PathItem.Parameters = nil
+	if cookie, ok := gen.buildHeaders(op)["Cookie"]; ok {
+		t.Errorf("expected no Cookie header, got: %q", cookie)
+	}
+}
+
+func TestBuildHeaders_CombinedSecurity(t *testing.T) {
+	spec := &openapi3.T{
+		Components: &openapi3.Components{
+			SecuritySchemes: openapi3.SecuritySchemes{
+				"key":     &openapi3.SecuritySchemeRef{Value: &openapi3.SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"}},
+				"session": &openapi3.SecuritySchemeRef{Value: &openapi3.SecurityScheme{Type: "apiKey", In: "cookie", Name: "SESSIONID"}},
+				"csrf":    &openapi3.SecuritySchemeRef{Value: &openapi3.SecurityScheme{Type: "apiKey", In: "cookie", Name: "CSRF"}},
+			},
+		},
+	}
+
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{Name: "theme", In: "cookie", Example: "dark"}},
+			},
+			// all schemes of a requirement are needed
+			Security: &openapi3.SecurityRequirements{
+				{"key": []string{}, "session": []string{}, "csrf": []string{}},
+			},
+		},
+	}
+
+	op := parser.Operation{
+		Path:      "/secure",
+		Method:    "GET",
+		Operation: pathItem.Get,
+		PathItem:  pathItem,
+	}
+
+	gen := NewGenerator(spec)
+	headers := gen.buildHeaders(op)
+
+	if headers["X-API-Key"] != "{{X-API-Key}}" {
+		t.Errorf("expected X-API-Key header, got: %q", headers["X-API-Key"])
+	}
+	expected := "theme=dark; CSRF={{CSRF}}; SESSIONID={{SESSIONID}}"
+	if headers["Cookie"] != expected {
+		t.Errorf("expected Cookie %q, got: %q", expected, headers["Cookie"])
+	}
+}
+
 func TestBuildSecurityHeaders_OAuth2(t *testing.T) {
 	spec := &openapi3.T{
 		Components: &openapi3.Components{
@@ -1099,56 +1695,51 @@ func TestBuildHTTPRequest_WithArrayQueryParameter(t *testing.T) {
 	}
 }
 
-func TestFormatParameterValue_Array(t *testing.T) {
-	gen := &Generator{}
+func TestBuildQueryString_Styles(t *testing.T) {
+	array := []any{"available", "pending"}
+	object := map[string]any{"color": "red", "size": 10}
 
 	tests := []struct {
 		name     string
-		input    interface{}
+		style    string
+		explode  *bool
+		value    any
 		expected string
 	}{
-		{
-			name:     "array with single string element",
-			input:    []interface{}{"available"},
-			expected: "available",
-		},
-		{
-			name:     "array with multiple elements returns first",
-			input:    []interface{}{"pending", "sold"},
-			expected: "pending",
-		},
-		{
-			name:     "array with integer element",
-			input:    []interface{}{123},
-			expected: "123",
-		},
-		{
-			name:     "empty array",
-			input:    []interface{}{},
-			expected: "[]",
-		},
-		{
-			name:     "non-array string",
-			input:    "simple-string",
-			expected: "simple-string",
-		},
-		{
-			name:     "non-array integer",
-			input:    42,
-			expected: "42",
-		},
-		{
-			name:     "non-array boolean",
-			input:    true,
-			expected: "true",
-		},
+		{"scalar", "", nil, "sold out", "status=sold%20out"},
+		{"form array", "", nil, array, "status=available&status=pending"},
+		{"form array without explode", "form", openapi3.BoolPtr(false), array, "status=available,pending"},
+		{"space delimited", "spaceDelimited", openapi3.BoolPtr(false), array, "status=available%20pending"},
+		{"pipe delimited", "pipeDelimited", openapi3.BoolPtr(false), array, "status=available|pending"},
+		{"pipe delimited with explode", "pipeDelimited", nil, array, "status=available&status=pending"},
+		{"form object", "", nil, object, "color=red&size=10"},
+		{"form object without explode", "form", openapi3.BoolPtr(false), object, "status=color,red,size,10"},
+		{"space delimited object", "spaceDelimited", openapi3.BoolPtr(false), object, "status=color%20red%20size%2010"},
+		{"pipe delimited object", "pipeDelimited", openapi3.BoolPtr(false), object, "status=color|red|size|10"},
+		{"deep object", "deepObject", nil, object, "status[color]=red&status[size]=10"},
+		{"nested deep object", "deepObject", nil, map[string]any{"price": map[string]any{"max": 10}}, "status[price][max]=10"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := gen.formatParameterValue(tt.input)
-			if result != tt.expected {
-				t.Errorf("expected %q, got %q", tt.expected, result)
+			pathItem := &openapi3.PathItem{
+				Get: &openapi3.Operation{
+					Parameters: openapi3.Parameters{
+						&openapi3.ParameterRef{Value: &openapi3.Parameter{
+							Name:    "status",
+							In:      "query",
+							Style:   tt.style,
+							Explode: tt.explode,
+							Example: tt.value,
+						}},
+					},
+				},
+			}
+			op := parser.Operation{Path: "/pets", Method: "GET", Operation: pathItem.Get, PathItem: pathItem}
+
+			gen := NewGenerator(&openapi3.T{})
+			if query := gen.buildQueryString(op); query != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, query)
 			}
 		})
 	}
@@ -1191,7 +1782,7 @@ func TestBuildQueryString_WithArrayParameter(t *testing.T) {
 	gen := NewGenerator(spec)
 	query := gen.buildQueryString(op)
 
-	// Should extract first element from generated array
+	// Should repeat the name for each item of the generated array
 	if !strings.Contains(query, "tags=tag1") {
 		t.Errorf("expected tags=tag1, got: %s", query)
 	}
@@ -1201,3 +1792,162 @@ func TestBuildQueryString_WithArrayParameter(t *testing.T) {
 		t.Errorf("query should not contain array brackets, got: %s", query)
 	}
 }
+
+func TestBuildRequestBody_LeavesOutReadOnly(t *testing.T) {
+	spec := &openapi3.T{}
+
+	pathItem := &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: &openapi3.RequestBody{
+					Content: openapi3.Content{
+						"application/json": &openapi3.MediaType{
+							Schema: &openapi3.SchemaRef{
+								Value: &openapi3.Schema{
+									Type: &openapi3.Types{"object"},
+									Properties: openapi3.Schemas{
+										"id":   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, ReadOnly: true}},
+										"name": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	op := parser.Operation{
+		Path:      "/users",
+		Method:    "POST",
+		Operation: pathItem.Post,
+		PathItem:  pathItem,
+	}
+
+	gen := NewGenerator(spec)
+	body, err := gen.buildRequestBody(op)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(body, `"id"`) {
+		t.Errorf("expected readOnly id to be left out of the request body, got: %s", body)
+	}
+
+	if !strings.Contains(body, `"name"`) {
+		t.Errorf("expected name in the request body, got: %s", body)
+	}
+}
+
+func TestBuildRequestBody_BodyModes(t *testing.T) {
+	spec := &openapi3.T{}
+
+	address := &openapi3.Schema{
+		Type:     &openapi3.Types{"object"},
+		Required: []string{"city"},
+		Properties: openapi3.Schemas{
+			"city":   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+			"street": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+		},
+	}
+	base := &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"name":     &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+			"nickname": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+		},
+	}
+	schema := &openapi3.Schema{
+		AllOf: openapi3.SchemaRefs{
+			&openapi3.SchemaRef{Value: base},
+			&openapi3.SchemaRef{Value: &openapi3.Schema{
+				Type:     &openapi3.Types{"object"},
+				Required: []string{"name", "address"},
+				Properties: openapi3.Schemas{
+					"address": &openapi3.SchemaRef{Value: address},
+					"age":     &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}},
+				},
+			}},
+		},
+	}
+
+	pathItem := &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: &openapi3.RequestBody{
+					Content: openapi3.Content{
+						"application/json": &openapi3.MediaType{
+							Schema:  &openapi3.SchemaRef{Value: schema},
+							Example: map[string]interface{}{"name": "full example"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	op := parser.Operation{
+		Path:      "/users",
+		Method:    "POST",
+		Operation: pathItem.Post,
+		PathItem:  pathItem,
+	}
+
+	tests := []struct {
+		mode    BodyMode
+		present []string
+		absent  []string
+	}{
+		{"", []string{`"full example"`}, nil},
+		{BodyFull, []string{`"full example"`}, nil},
+		{BodyRequired, []string{`"name"`, `"address"`, `"city"`}, []string{`"nickname"`, `"age"`, `"street"`, `"full example"`}},
+		{BodyNone, []string{"{{body}}"}, []string{`"name"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			gen := NewGenerator(spec)
+			gen.Body = tt.mode
+
+			body, err := gen.buildRequestBody(op)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, s := range tt.present {
+				if !strings.Contains(body, s) {
+					t.Errorf("expected %s in body, got: %s", s, body)
+				}
+			}
+			for _, s := range tt.absent {
+				if strings.Contains(body, s) {
+					t.Errorf("expected no %s in body, got: %s", s, body)
+				}
+			}
+		})
+	}
+}
+
+func TestMediaTypeSuffix(t *testing.T) {
+	tests := []struct {
+		mediaType string
+		full      bool
+		expected  string
+	}{
+		{"application/json", false, "json"},
+		{"application/xml; charset=utf-8", false, "xml"},
+		{"application/merge-patch+json", false, "merge_patch_json"},
+		{"application/vnd.api+json", false, "api_json"},
+		{"application/x-yaml", false, "yaml"},
+		{"multipart/form-data", false, "multipart"},
+		{"text/xml", true, "text_xml"},
+	}
+
+	for _, tt := range tests {
+		if got := mediaTypeSuffix(tt.mediaType, tt.full); got != tt.expected {
+			t.Errorf("mediaTypeSuffix(%q, %v): expected %q, got %q", tt.mediaType, tt.full, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kalli/openapi-http/internal/parser"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ExampleIssue is an example that doesn't match its schema
+type ExampleIssue struct {
+	Operation string // operationId, or method and path when there is none
+	Location  string // where the example is, e.g. "requestBody application/json examples/cat"
+	Pointer   string // JSON pointer to the invalid value within the example, empty for the whole example
+	Message   string
+}
+
+func (i ExampleIssue) String() string {
+	return fmt.Sprintf("%s: %s: #%s: %s", i.Operation, i.Location, i.Pointer, i.Message)
+}
+
+// LintExamples validates the explicit examples of the operations' parameters, request
+// bodies and responses, as well as the examples that would be generated for them, against
+// their schemas
+func (g *Generator) LintExamples(ops []parser.Operation) []ExampleIssue {
+	var issues []ExampleIssue
+
+	for _, op := range ops {
+		name := op.Operation.OperationID
+		if name == "" {
+			name = op.Method + " " + op.Path
+		}
+
+		// generated values should be the ones that end up in the request
+		g.reseed(op.Method + " " + op.Path)
+
+		for _, in := range []string{"path", "query", "header", "cookie"} {
+			for _, param := range g.collectParameters(op, in) {
+				location := fmt.Sprintf("parameter %s %s", in, param.Name)
+				if param.Schema == nil && len(param.Content) > 0 {
+					issues = append(issues, g.lintParameterContent(name, location, param)...)
+					continue
+				}
+				issues = append(issues, g.lintContent(name, location, param.Schema, param.Example, param.Examples, false)...)
+			}
+		}
+
+		if op.Operation.RequestBody != nil && op.Operation.RequestBody.Value != nil {
+			content := op.Operation.RequestBody.Value.Content
+			for _, mt := range slices.Sorted(maps.Keys(content)) {
+				location := "requestBody " + mt
+				issues = append(issues, g.lintMediaType(name, location, mt, content[mt], false)...)
+			}
+		}
+
+		if op.Operation.Responses != nil {
+			responses := op.Operation.Responses.Map()
+			for _, status := range slices.Sorted(maps.Keys(responses)) {
+				response := responses[status]
+				if response == nil || response.Value == nil {
+					continue
+				}
+				for _, mt := range slices.Sorted(maps.Keys(response.Value.Content)) {
+					location := fmt.Sprintf("responses %s %s", status, mt)
+					issues = append(issues, g.lintMediaType(name, location, mt, response.Value.Content[mt], true)...)
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+// lintParameterContent validates the examples of a parameter described with content
+// rather than a schema. Examples of the parameter itself are for the content's schema.
+func (g *Generator) lintParameterContent(operation, location string, param *openapi3.Parameter) []ExampleIssue {
+	var issues []ExampleIssue
+	for _, mediaType := range slices.Sorted(maps.Keys(param.Content)) {
+		mt := param.Content[mediaType]
+		if mt != nil && mt.Example == nil && len(mt.Examples) == 0 {
+			withExamples := *mt
+			withExamples.Example, withExamples.Examples = param.Example, param.Examples
+			mt = &withExamples
+		}
+		issues = append(issues, g.lintMediaType(operation, location+" "+mediaType, mediaType, mt, false)...)
+	}
+	return issues
+}
+
+// lintMediaType validates the examples of a request or response body
+func (g *Generator) lintMediaType(operation, location, mediaType string, mt *openapi3.MediaType, response bool) []ExampleIssue {
+	if mt == nil || mt.Schema == nil || mt.Schema.Value == nil {
+		return nil
+	}
+
+	// examples of non JSON bodies, like XML, are usually given in their serialized form
+	serialized := func(value any) bool {
+		_, ok := value.(string)
+		return ok && !isJSONMediaType(mediaType) && getSchemaType(mt.Schema.Value) != "string"
+	}
+
+	example := mt.Example
+	if serialized(example) {
+		example = nil
+	}
+	examples := make(openapi3.Examples)
+	for key, ex := range mt.Examples {
+		if ex != nil && ex.Value != nil && !serialized(ex.Value.Value) {
+			examples[key] = ex
+		}
+	}
+
+	return g.lintContent(operation, location, mt.Schema, example, examples, response)
+}
+
+// lintContent validates an example, the named examples and the generated example against
+// a schema
+func (g *Generator) lintContent(operation, location string, schema *openapi3.SchemaRef, example any, examples openapi3.Examples, response bool) []ExampleIssue {
+	if schema == nil || schema.Value == nil {
+		return nil
+	}
+
+	var issues []ExampleIssue
+	if example != nil {
+		issues = append(issues, g.lintValue(operation, location+" example", schema.Value, example, response)...)
+	}
+	for _, key := range slices.Sorted(maps.Keys(examples)) {
+		ex := examples[key]
+		if ex == nil || ex.Value == nil || ex.Value.Value == nil {
+			continue
+		}
+		issues = append(issues, g.lintValue(operation, location+" examples/"+key, schema.Value, ex.Value.Value, response)...)
+	}
+
+	var generated any
+	if response {
+		generated = g.generateResponseExample(schema)
+	} else {
+		generated = g.generateRequestExample(schema)
+	}
+	if generated != nil {
+		issues = append(issues, g.lintValue(operation, location+" generated", schema.Value, generated, response)...)
+	}
+
+	return issues
+}
+
+// lintValue validates a single example, which is round tripped through JSON first so
+// it is checked the way it ends up in a request
+func (g *Generator) lintValue(operation, location string, schema *openapi3.Schema, value any, response bool) []ExampleIssue {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return []ExampleIssue{{Operation: operation, Location: location, Message: err.Error()}}
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return []ExampleIssue{{Operation: operation, Location: location, Message: err.Error()}}
+	}
+
+	opts := []openapi3.SchemaValidationOption{openapi3.VisitAsRequest(), openapi3.MultiErrors()}
+	if response {
+		opts[0] = openapi3.VisitAsResponse()
+	}
+	if g.spec != nil && g.spec.IsOpenAPI31OrLater() {
+		opts = append(opts, openapi3.EnableJSONSchema2020())
+	}
+
+	var issues []ExampleIssue
+	for _, err := range flattenErrors(schema.VisitJSON(decoded, opts...)) {
+		issue := ExampleIssue{Operation: operation, Location: location, Message: err.Error()}
+		var schemaErr *openapi3.SchemaError
+		if errors.As(err, &schemaErr) {
+			issue.Pointer = jsonPointer(schemaErr.JSONPointer())
+			issue.Message = schemaErr.Reason
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// flattenErrors unpacks the (nested) MultiErrors returned by schema validation
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		var errs []error
+		for _, e := range multi {
+			errs = append(errs, flattenErrors(e)...)
+		}
+		return errs
+	}
+	return []error{err}
+}
+
+// jsonPointer builds an RFC 6901 JSON pointer from its reference tokens
+func jsonPointer(tokens []string) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteString("/")
+		token = strings.ReplaceAll(token, "~", "~0")
+		sb.WriteString(strings.ReplaceAll(token, "/", "~1"))
+	}
+	return sb.String()
+}
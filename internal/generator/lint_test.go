@@ -0,0 +1,194 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/kalli/openapi-http/internal/parser"
+)
+
+func TestLintExamples(t *testing.T) {
+	pet := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Pet",
+		Value: &openapi3.Schema{
+			Type:     &openapi3.Types{"object"},
+			Required: []string{"name"},
+			Properties: openapi3.Schemas{
+				"id":   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, ReadOnly: true}},
+				"name": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+				"tags": &openapi3.SchemaRef{Value: &openapi3.Schema{
+					Type:  &openapi3.Types{"array"},
+					Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+				}},
+			},
+		},
+	}
+
+	responses := openapi3.NewResponses()
+	responses.Set("200", &openapi3.ResponseRef{Value: &openapi3.Response{
+		Content: openapi3.Content{
+			"application/json": &openapi3.MediaType{Schema: pet, Example: map[string]any{"id": "x", "name": "rex"}},
+		},
+	}})
+
+	pathItem := &openapi3.PathItem{
+		Parameters: openapi3.Parameters{
+			&openapi3.ParameterRef{Value: &openapi3.Parameter{
+				Name:    "id",
+				In:      "path",
+				Schema:  &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}},
+				Example: "abc",
+			}},
+		},
+		Post: &openapi3.Operation{
+			OperationID: "addPet",
+			RequestBody: &openapi3.RequestBodyRef{Value: &openapi3.RequestBody{
+				Content: openapi3.Content{
+					"application/json": &openapi3.MediaType{
+						Schema: pet,
+						Examples: openapi3.Examples{
+							"valid":   &openapi3.ExampleRef{Value: &openapi3.Example{Value: map[string]any{"name": "rex"}}},
+							"invalid": &openapi3.ExampleRef{Value: &openapi3.Example{Value: map[string]any{"name": 5, "tags": []any{"a", 3}}}},
+						},
+					},
+					// serialized examples of other media types are not checked
+					"application/xml": &openapi3.MediaType{Schema: pet, Example: "<pet><name>rex</name></pet>"},
+				},
+			}},
+			Responses: responses,
+		},
+	}
+
+	op := parser.Operation{Path: "/pets/{id}", Method: "POST", Operation: pathItem.Post, PathItem: pathItem}
+
+	gen := NewGenerator(&openapi3.T{OpenAPI: "3.0.3"})
+	issues := gen.LintExamples([]parser.Operation{op})
+
+	expected := []ExampleIssue{
+		{Operation: "addPet", Location: "parameter path id example", Pointer: "", Message: "value must be an integer"},
+		{Operation: "addPet", Location: "requestBody application/json examples/invalid", Pointer: "/name", Message: "value must be a string"},
+		{Operation: "addPet", Location: "requestBody application/json examples/invalid", Pointer: "/tags/1", Message: "value must be a string"},
+		{Operation: "addPet", Location: "responses 200 application/json example", Pointer: "/id", Message: "value must be an integer"},
+	}
+
+	if len(issues) != len(expected) {
+		t.Fatalf("expected %d issues, got %d: %v", len(expected), len(issues), issues)
+	}
+	for i, issue := range issues {
+		if issue != expected[i] {
+			t.Errorf("issue %d: expected %v, got %v", i, expected[i], issue)
+		}
+	}
+}
+
+func TestLintExamples_ParameterContent(t *testing.T) {
+	filter := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"size": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}},
+		},
+	}}
+
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			OperationID: "findPets",
+			Parameters: openapi3.Parameters{
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{
+					Name: "filter",
+					In:   "query",
+					Content: openapi3.Content{
+						"application/json": &openapi3.MediaType{
+							Schema: filter,
+							Examples: openapi3.Examples{
+								"large": &openapi3.ExampleRef{Value: &openapi3.Example{Value: map[string]any{"size": "large"}}},
+							},
+						},
+					},
+				}},
+				// examples of the parameter itself are for the content's schema
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{
+					Name:    "exclude",
+					In:      "query",
+					Content: openapi3.Content{"application/json": &openapi3.MediaType{Schema: filter}},
+					Example: map[string]any{"size": true},
+				}},
+			},
+		},
+	}
+
+	op := parser.Operation{Path: "/pets", Method: "GET", Operation: pathItem.Get, PathItem: pathItem}
+
+	gen := NewGenerator(&openapi3.T{OpenAPI: "3.0.3"})
+	issues := gen.LintExamples([]parser.Operation{op})
+
+	expected := []ExampleIssue{
+		{Operation: "findPets", Location: "parameter query filter application/json examples/large", Pointer: "/size", Message: "value must be an integer"},
+		{Operation: "findPets", Location: "parameter query exclude application/json example", Pointer: "/size", Message: "value must be an integer"},
+	}
+
+	if len(issues) != len(expected) {
+		t.Fatalf("expected %d issues, got %d: %v", len(expected), len(issues), issues)
+	}
+	for i, issue := range issues {
+		if issue != expected[i] {
+			t.Errorf("issue %d: expected %v, got %v", i, expected[i], issue)
+		}
+	}
+}
+
+func TestLintExamples_Generated(t *testing.T) {
+	// the example doesn't match the format, so neither does the generated value
+	schema := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type:    &openapi3.Types{"string"},
+		Format:  "date",
+		Example: "yesterday",
+	}}
+
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				&openapi3.ParameterRef{Value: &openapi3.Parameter{Name: "since", In: "query", Schema: schema}},
+			},
+		},
+	}
+	op := parser.Operation{Path: "/pets", Method: "GET", Operation: pathItem.Get, PathItem: pathItem}
+
+	issues := NewGenerator(&openapi3.T{}).LintExamples([]parser.Operation{op})
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %v", issues)
+	}
+	if issues[0].Operation != "GET /pets" || issues[0].Location != "parameter query since generated" {
+		t.Errorf("unexpected issue %v", issues[0])
+	}
+}
+
+func TestLintExamples_ValidSpec(t *testing.T) {
+	for _, path := range []string{"../../test/petstore.yml", "../../test/openapi31.yml"} {
+		spec, err := parser.LoadSpec(path)
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", path, err)
+		}
+
+		issues := NewGenerator(spec).LintExamples(parser.FindOperations(spec, "", "", ""))
+		if len(issues) > 0 {
+			t.Errorf("expected no issues for %s, got %v", path, issues)
+		}
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	tests := []struct {
+		tokens   []string
+		expected string
+	}{
+		{nil, ""},
+		{[]string{"tags", "1"}, "/tags/1"},
+		{[]string{"a/b", "m~n"}, "/a~1b/m~0n"},
+	}
+
+	for _, tt := range tests {
+		if got := jsonPointer(tt.tokens); got != tt.expected {
+			t.Errorf("jsonPointer(%v): expected %q, got %q", tt.tokens, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// multipartBoundary separates the parts of multipart bodies
+const multipartBoundary = "WebAppBoundary"
+
+// fileExtensions are the file extensions used for file parts of common content types,
+// other content types get .bin
+var fileExtensions = map[string]string{
+	"application/json": ".json",
+	"application/pdf":  ".pdf",
+	"application/xml":  ".xml",
+	"application/zip":  ".zip",
+	"image/gif":        ".gif",
+	"image/jpeg":       ".jpg",
+	"image/png":        ".png",
+	"image/svg+xml":    ".svg",
+	"image/webp":       ".webp",
+	"text/csv":         ".csv",
+	"text/plain":       ".txt",
+}
+
+// encodeMultipart serializes a request body as multipart/form-data, with a part per
+// property, and for arrays a part per item. Binary properties become file parts referencing
+// a file in the fixtures directory, named after the property. The content type and headers
+// of each part come from the media type's encoding.
+func (g *Generator) encodeMultipart(data any, mediaType *openapi3.MediaType) string {
+	obj, ok := data.(map[string]any)
+	if !ok {
+		// e.g. an example that is already serialized
+		return scalarString(data)
+	}
+
+	var schema *openapi3.Schema
+	if mediaType.Schema != nil {
+		schema = mediaType.Schema.Value
+	}
+
+	var sb strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(obj)) {
+		propSchema := propertySchema(schema, name)
+		enc := mediaType.Encoding[name]
+
+		// arrays are sent as a part per item, their content type is that of the items,
+		// unless the encoding asks for the whole array as JSON
+		values, item := []any{obj[name]}, false
+		if items, ok := obj[name].([]any); ok && (enc == nil || !isJSONMediaType(enc.ContentType)) {
+			values, item = items, true
+			if propSchema != nil {
+				propSchema = schemaValue(propSchema.Items)
+			}
+		}
+
+		for i, value := range values {
+			file := name
+			if len(values) > 1 {
+				file = fmt.Sprintf("%s%d", name, i+1)
+			}
+			g.writePart(&sb, name, file, value, propSchema, enc, item)
+		}
+	}
+	sb.WriteString("--" + multipartBoundary + "--")
+
+	return sb.String()
+}
+
+// writePart writes a single part of a multipart body, file is the name of the file to
+// upload for binary parts. Array items that are primitives are text/plain.
+func (g *Generator) writePart(sb *strings.Builder, name, file string, value any, schema *openapi3.Schema, enc *openapi3.Encoding, item bool) {
+	binary := isBinarySchema(schema)
+
+	contentType := ""
+	switch {
+	case enc != nil && enc.ContentType != "":
+		// a list of content types is allowed, the first one will do
+		contentType, _, _ = strings.Cut(enc.ContentType, ",")
+		contentType = strings.TrimSpace(contentType)
+	case binary && schema.ContentMediaType != "":
+		contentType = schema.ContentMediaType
+	case binary:
+		contentType = "application/octet-stream"
+	default:
+		switch value.(type) {
+		case map[string]any, []any:
+			contentType = "application/json"
+		default:
+			if item {
+				contentType = "text/plain"
+			}
+		}
+	}
+
+	sb.WriteString("--" + multipartBoundary + "\n")
+	if binary {
+		fmt.Fprintf(sb, "Content-Disposition: form-data; name=%q; filename=%q\n", name, fileName(file, contentType))
+	} else {
+		fmt.Fprintf(sb, "Content-Disposition: form-data; name=%q\n", name)
+	}
+	if contentType != "" {
+		fmt.Fprintf(sb, "Content-Type: %s\n", contentType)
+	}
+	if enc != nil {
+		for _, header := range slices.Sorted(maps.Keys(enc.Headers)) {
+			// the part's content type is set by contentType
+			if strings.EqualFold(header, "Content-Type") {
+				continue
+			}
+			fmt.Fprintf(sb, "%s: %s\n", header, g.headerValue(header, enc.Headers[header]))
+		}
+	}
+	sb.WriteString("\n")
+
+	if binary {
+		sb.WriteString(g.fixtureRef(fileName(file, contentType)))
+	} else {
+		// objects and arrays come out as JSON
+		sb.WriteString(scalarString(value))
+	}
+	sb.WriteString("\n")
+}
+
+// headerValue returns an example value for a header of a multipart part
+func (g *Generator) headerValue(name string, header *openapi3.HeaderRef) string {
+	if header == nil || header.Value == nil {
+		return "{{" + name + "}}"
+	}
+	if header.Value.Example != nil {
+		return scalarString(header.Value.Example)
+	}
+	if header.Value.Schema != nil {
+		if example := g.generateRequestExample(header.Value.Schema); example != nil {
+			return scalarString(example)
+		}
+	}
+	return "{{" + name + "}}"
+}
+
+// propertySchema finds the schema of a named property, including those declared by allOf
+// members
+func propertySchema(schema *openapi3.Schema, name string) *openapi3.Schema {
+	if schema == nil {
+		return nil
+	}
+	if prop, ok := schema.Properties[name]; ok && prop.Value != nil {
+		return prop.Value
+	}
+	for _, member := range schema.AllOf {
+		if member.Value != nil {
+			if prop := propertySchema(member.Value, name); prop != nil {
+				return prop
+			}
+		}
+	}
+	return nil
+}
+
+// isBinarySchema checks whether a schema describes file contents: format binary in
+// OpenAPI 3.0, or a contentMediaType without contentEncoding in 3.1
+func isBinarySchema(schema *openapi3.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	return schema.Format == "binary" || (schema.ContentMediaType != "" && schema.ContentEncoding == "")
+}
+
+// fileName names the file to upload for a property, with an extension matching the
+// content type
+func fileName(name, contentType string) string {
+	contentType, _, _ = strings.Cut(contentType, ";")
+	ext, ok := fileExtensions[strings.TrimSpace(strings.ToLower(contentType))]
+	if !ok {
+		ext = ".bin"
+	}
+	return name + ext
+}
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestEncodeMultipart(t *testing.T) {
+	mediaType := &openapi3.MediaType{
+		Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{
+			Type: &openapi3.Types{"object"},
+			Properties: openapi3.Schemas{
+				"name": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+				"file": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "binary"}},
+				"photos": &openapi3.SchemaRef{Value: &openapi3.Schema{
+					Type:  &openapi3.Types{"array"},
+					Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, ContentMediaType: "image/jpeg"}},
+				}},
+				"meta": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}}},
+				"labels": &openapi3.SchemaRef{Value: &openapi3.Schema{
+					Type:  &openapi3.Types{"array"},
+					Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+				}},
+				"sizes": &openapi3.SchemaRef{Value: &openapi3.Schema{
+					Type:  &openapi3.Types{"array"},
+					Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}},
+				}},
+				"owners": &openapi3.SchemaRef{Value: &openapi3.Schema{
+					Type:  &openapi3.Types{"array"},
+					Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}}},
+				}},
+			},
+		}},
+		Encoding: map[string]*openapi3.Encoding{
+			"file": {
+				ContentType: "image/png, image/gif",
+				Headers: openapi3.Headers{
+					"X-Checksum": &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{Example: "abc123"}}},
+				},
+			},
+			"sizes": {ContentType: "application/json"},
+		},
+	}
+	data := map[string]any{
+		"name":   "Rex",
+		"file":   "binary",
+		"photos": []any{"binary", "binary"},
+		"meta":   map[string]any{"size": 10},
+		"labels": []any{"good", "boy"},
+		"sizes":  []any{1, 2},
+		"owners": []any{map[string]any{"name": "Jane"}},
+	}
+
+	expected := `--WebAppBoundary
+Content-Disposition: form-data; name="file"; filename="file.png"
+Content-Type: image/png
+X-Checksum: abc123
+
+< ./fixtures/file.png
+--WebAppBoundary
+Content-Disposition: form-data; name="labels"
+Content-Type: text/plain
+
+good
+--WebAppBoundary
+Content-Disposition: form-data; name="labels"
+Content-Type: text/plain
+
+boy
+--WebAppBoundary
+Content-Disposition: form-data; name="meta"
+Content-Type: application/json
+
+{"size":10}
+--WebAppBoundary
+Content-Disposition: form-data; name="name"
+
+Rex
+--WebAppBoundary
+Content-Disposition: form-data; name="owners"
+Content-Type: application/json
+
+{"name":"Jane"}
+--WebAppBoundary
+Content-Disposition: form-data; name="photos"; filename="photos1.jpg"
+Content-Type: image/jpeg
+
+< ./fixtures/photos1.jpg
+--WebAppBoundary
+Content-Disposition: form-data; name="photos"; filename="photos2.jpg"
+Content-Type: image/jpeg
+
+< ./fixtures/photos2.jpg
+--WebAppBoundary
+Content-Disposition: form-data; name="sizes"
+Content-Type: application/json
+
+[1,2]
+--WebAppBoundary--`
+
+	if result := NewGenerator(&openapi3.T{}).encodeMultipart(data, mediaType); result != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, result)
+	}
+}
+
+func TestIsBinarySchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   *openapi3.Schema
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"string", &openapi3.Schema{Type: &openapi3.Types{"string"}}, false},
+		{"format binary", &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "binary"}, true},
+		{"content media type", &openapi3.Schema{ContentMediaType: "image/png"}, true},
+		{"base64 encoded", &openapi3.Schema{ContentMediaType: "image/png", ContentEncoding: "base64"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := isBinarySchema(tt.schema); got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// numericBounds are the limits a number has to fall within
+type numericBounds struct {
+	lower, upper         *float64
+	exclLower, exclUpper bool
+}
+
+// getNumericBounds combines minimum and maximum with both OpenAPI 3.0 boolean
+// exclusiveMinimum/Maximum and OpenAPI 3.1 numeric ones
+func getNumericBounds(schema *openapi3.Schema) numericBounds {
+	b := numericBounds{lower: schema.Min, upper: schema.Max}
+
+	// 3.0: exclusiveMinimum modifies minimum
+	if schema.ExclusiveMin.IsTrue() && schema.Min != nil {
+		b.exclLower = true
+	}
+	if schema.ExclusiveMax.IsTrue() && schema.Max != nil {
+		b.exclUpper = true
+	}
+
+	// 3.1: exclusiveMinimum is a bound of its own, the tightest one applies
+	if v := schema.ExclusiveMin.Value; v != nil && (b.lower == nil || *v >= *b.lower) {
+		b.lower, b.exclLower = v, true
+	}
+	if v := schema.ExclusiveMax.Value; v != nil && (b.upper == nil || *v <= *b.upper) {
+		b.upper, b.exclUpper = v, true
+	}
+
+	return b
+}
+
+// contains checks whether v is within the bounds
+func (b numericBounds) contains(v float64) bool {
+	if b.lower != nil && (v < *b.lower || (b.exclLower && v == *b.lower)) {
+		return false
+	}
+	if b.upper != nil && (v > *b.upper || (b.exclUpper && v == *b.upper)) {
+		return false
+	}
+	return true
+}
+
+// generateInteger creates an example integer satisfying the schema's bounds, multipleOf
+// and format
+func (g *Generator) generateInteger(schema *openapi3.Schema) int {
+	if g.Realistic {
+		if v, ok := g.realisticNumberFor(schema, true); ok {
+			return int(v)
+		}
+	}
+	if g.rand != nil {
+		if v, ok := g.randomNumeric(schema, true); ok {
+			return int(v)
+		}
+	}
+	return int(generateNumeric(schema, true))
+}
+
+// generateNumber creates an example number satisfying the schema's bounds, multipleOf
+// and format
+func (g *Generator) generateNumber(schema *openapi3.Schema) float64 {
+	if g.Realistic {
+		if v, ok := g.realisticNumberFor(schema, false); ok {
+			return v
+		}
+	}
+	if g.rand != nil {
+		if v, ok := g.randomNumeric(schema, false); ok {
+			return v
+		}
+	}
+	return generateNumeric(schema, false)
+}
+
+// generateNumeric tries a few candidate values, starting with the minimum (or zero when
+// there is none), and returns the first one satisfying all constraints. If none does,
+// e.g. because the constraints contradict each other, the first candidate is returned.
+func generateNumeric(schema *openapi3.Schema, integer bool) float64 {
+	b := getNumericBounds(schema)
+
+	var candidates []float64
+	if b.lower != nil {
+		candidates = append(candidates, *b.lower, *b.lower+1)
+		if b.upper != nil {
+			candidates = append(candidates, (*b.lower+*b.upper)/2)
+		}
+	} else {
+		candidates = append(candidates, 0)
+	}
+	if b.upper != nil {
+		candidates = append(candidates, *b.upper, *b.upper-1)
+	}
+
+	for _, c := range candidates {
+		tries := []float64{c}
+		if integer {
+			tries = []float64{math.Ceil(c), math.Floor(c)}
+		}
+		if m := schema.MultipleOf; m != nil && *m > 0 {
+			var multiples []float64
+			for _, t := range tries {
+				multiples = append(multiples, ceilMultiple(t, *m), ceilMultiple(t, *m)+*m, floorMultiple(t, *m), floorMultiple(t, *m)-*m)
+			}
+			tries = multiples
+		}
+
+		for _, v := range tries {
+			if numericValid(schema, b, v, integer) {
+				return v
+			}
+		}
+	}
+
+	if integer {
+		return math.Ceil(candidates[0])
+	}
+	return candidates[0]
+}
+
+// numericValid checks whether v satisfies the schema's numeric constraints
+func numericValid(schema *openapi3.Schema, b numericBounds, v float64, integer bool) bool {
+	if !b.contains(v) || !withinFormat(v, schema.Format) {
+		return false
+	}
+	if integer && v != math.Trunc(v) {
+		return false
+	}
+	if m := schema.MultipleOf; m != nil && *m > 0 && !isMultipleOf(v, *m) {
+		return false
+	}
+	return true
+}
+
+// withinFormat checks that v is representable in the numeric format
+func withinFormat(v float64, format string) bool {
+	switch format {
+	case "int32":
+		return v >= math.MinInt32 && v <= math.MaxInt32
+	case "int64":
+		return v >= math.MinInt64 && v <= math.MaxInt64
+	case "float":
+		return math.Abs(v) <= math.MaxFloat32
+	}
+	return true
+}
+
+// isMultipleOf checks whether v is a multiple of m, allowing for floating point errors
+func isMultipleOf(v, m float64) bool {
+	q := v / m
+	return math.Abs(q-math.Round(q)) < 1e-9
+}
+
+// ceilMultiple returns the smallest multiple of m that is at least v
+func ceilMultiple(v, m float64) float64 {
+	return roundLike(math.Ceil(v/m-1e-9)*m, m)
+}
+
+// floorMultiple returns the largest multiple of m that is at most v
+func floorMultiple(v, m float64) float64 {
+	return roundLike(math.Floor(v/m+1e-9)*m, m)
+}
+
+// roundLike rounds v to as many decimals as m has, so multiples of 0.1 come out as
+// 0.3 rather than 0.30000000000000004
+func roundLike(v, m float64) float64 {
+	s := strconv.FormatFloat(m, 'f', -1, 64)
+	decimals := 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		decimals = len(s) - i - 1
+	}
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(v*scale) / scale
+}
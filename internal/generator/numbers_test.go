@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerateExample_IntegerConstraints(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		expected int
+	}{
+		{"maximum below zero", `{"type": "integer", "maximum": -5}`, -5},
+		{"exclusive minimum 3.0", `{"type": "integer", "minimum": 1, "exclusiveMinimum": true}`, 2},
+		{"exclusive maximum 3.0", `{"type": "integer", "maximum": 0, "exclusiveMaximum": true}`, -1},
+		{"exclusive minimum 3.1", `{"type": "integer", "exclusiveMinimum": 5}`, 6},
+		{"exclusive maximum 3.1", `{"type": "integer", "exclusiveMaximum": -2}`, -3},
+		{"fractional minimum", `{"type": "integer", "minimum": 2.5}`, 3},
+		{"multiple of", `{"type": "integer", "minimum": 1, "multipleOf": 5}`, 5},
+		{"multiple of without bounds", `{"type": "integer", "multipleOf": 7}`, 0},
+		{"multiple of within range", `{"type": "integer", "minimum": 11, "maximum": 20, "multipleOf": 10}`, 20},
+		{"int32 format", `{"type": "integer", "format": "int32", "minimum": 3}`, 3},
+	}
+
+	gen := &Generator{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var schema openapi3.Schema
+			if err := json.Unmarshal([]byte(tt.schema), &schema); err != nil {
+				t.Fatalf("failed to parse schema: %v", err)
+			}
+
+			result := gen.generateExample(&schema)
+
+			if result != tt.expected {
+				t.Errorf("expected %d, got: %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateExample_NumberConstraints(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		expected float64
+	}{
+		{"minimum", `{"type": "number", "minimum": 1.5}`, 1.5},
+		{"exclusive minimum 3.0", `{"type": "number", "minimum": 1, "exclusiveMinimum": true}`, 2},
+		{"exclusive bounds 3.0", `{"type": "number", "minimum": 0, "maximum": 1, "exclusiveMinimum": true, "exclusiveMaximum": true}`, 0.5},
+		{"exclusive minimum 3.1", `{"type": "number", "exclusiveMinimum": 0, "maximum": 0.5}`, 0.25},
+		{"multiple of", `{"type": "number", "minimum": 0.25, "multipleOf": 0.1}`, 0.3},
+		{"multiple of with exclusive minimum", `{"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.01}`, 0.01},
+		{"maximum below zero", `{"type": "number", "maximum": -0.5, "format": "double"}`, -0.5},
+	}
+
+	gen := &Generator{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var schema openapi3.Schema
+			if err := json.Unmarshal([]byte(tt.schema), &schema); err != nil {
+				t.Fatalf("failed to parse schema: %v", err)
+			}
+
+			result := gen.generateExample(&schema)
+
+			if result != tt.expected {
+				t.Errorf("expected %v, got: %v", tt.expected, result)
+			}
+
+			if err := schema.VisitJSON(result); err != nil {
+				t.Errorf("generated value doesn't validate: %v", err)
+			}
+		})
+	}
+}
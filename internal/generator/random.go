@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SetSeed makes the generator pick random values within the schema constraints instead
+// of always the same ones: enum members, numbers, array lengths and pattern characters.
+// The same seed always gives the same output.
+func (g *Generator) SetSeed(seed int64) {
+	g.seed = uint64(seed)
+	g.seeded = true
+	g.rand = rand.New(rand.NewPCG(g.seed, 0))
+}
+
+// reseed restarts the random source for an operation, so the values generated for it
+// don't depend on which other operations were generated before
+func (g *Generator) reseed(key string) {
+	if !g.seeded {
+		return
+	}
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	g.rand = rand.New(rand.NewPCG(g.seed, h.Sum64()))
+}
+
+// intn returns a random number in [0, n), or 0 when not seeded
+func (g *Generator) intn(n int) int {
+	if g.rand == nil || n <= 1 {
+		return 0
+	}
+	return g.rand.IntN(n)
+}
+
+// randomNumeric picks a random number within the schema's bounds, or within 100 of the
+// bound that is set. It reports false if it can't find one that satisfies all constraints.
+func (g *Generator) randomNumeric(schema *openapi3.Schema, integer bool) (float64, bool) {
+	b := getNumericBounds(schema)
+
+	lo, hi := 0.0, 100.0
+	switch {
+	case b.lower != nil && b.upper != nil:
+		lo, hi = *b.lower, *b.upper
+	case b.lower != nil:
+		lo, hi = *b.lower, *b.lower+100
+	case b.upper != nil:
+		lo, hi = *b.upper-100, *b.upper
+	}
+
+	for range 10 {
+		v := lo + g.rand.Float64()*(hi-lo)
+		if integer {
+			v = math.Round(v)
+		} else {
+			v = math.Round(v*100) / 100
+		}
+		if m := schema.MultipleOf; m != nil && *m > 0 {
+			v = ceilMultiple(v, *m)
+		}
+
+		if numericValid(schema, b, v, integer) {
+			return v, true
+		}
+	}
+
+	return 0, false
+}
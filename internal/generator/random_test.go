@@ -0,0 +1,194 @@
+package generator
+
+import (
+	"reflect"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/kalli/openapi-http/internal/parser"
+)
+
+func seededSchema() *openapi3.Schema {
+	minVal, maxVal, multiple := 10.0, 50.0, 5.0
+	maxItems := uint64(2)
+
+	return &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"status": &openapi3.SchemaRef{Value: &openapi3.Schema{
+				Type: &openapi3.Types{"string"},
+				Enum: []interface{}{"available", "pending", "sold"},
+			}},
+			"count": &openapi3.SchemaRef{Value: &openapi3.Schema{
+				Type:       &openapi3.Types{"integer"},
+				Min:        &minVal,
+				Max:        &maxVal,
+				MultipleOf: &multiple,
+			}},
+			"ratio": &openapi3.SchemaRef{Value: &openapi3.Schema{
+				Type: &openapi3.Types{"number"},
+				Min:  &minVal,
+				Max:  &maxVal,
+			}},
+			"code": &openapi3.SchemaRef{Value: &openapi3.Schema{
+				Type:    &openapi3.Types{"string"},
+				Pattern: `^[A-Z]{3}-\d{4}$`,
+			}},
+			"tags": &openapi3.SchemaRef{Value: &openapi3.Schema{
+				Type:     &openapi3.Types{"array"},
+				MaxItems: &maxItems,
+				Items:    &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"boolean"}}},
+			}},
+		},
+	}
+}
+
+func TestSetSeed_Reproducible(t *testing.T) {
+	schema := seededSchema()
+
+	first := &Generator{}
+	first.SetSeed(42)
+	second := &Generator{}
+	second.SetSeed(42)
+
+	a := first.generateExample(schema)
+	b := second.generateExample(schema)
+
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("expected the same seed to give the same example, got: %v and %v", a, b)
+	}
+}
+
+func TestSetSeed_RespectsConstraints(t *testing.T) {
+	schema := seededSchema()
+	code := regexp.MustCompile(schema.Properties["code"].Value.Pattern)
+
+	for seed := range int64(50) {
+		gen := &Generator{}
+		gen.SetSeed(seed)
+
+		obj, ok := gen.generateExample(schema).(map[string]interface{})
+		if !ok {
+			t.Fatal("expected object")
+		}
+
+		if !slices.Contains(schema.Properties["status"].Value.Enum, obj["status"]) {
+			t.Errorf("seed %d: status %v is not an enum member", seed, obj["status"])
+		}
+
+		count, ok := obj["count"].(int)
+		if !ok || count < 10 || count > 50 || count%5 != 0 {
+			t.Errorf("seed %d: count %v doesn't satisfy constraints", seed, obj["count"])
+		}
+
+		ratio, ok := obj["ratio"].(float64)
+		if !ok || ratio < 10 || ratio > 50 {
+			t.Errorf("seed %d: ratio %v is out of bounds", seed, obj["ratio"])
+		}
+
+		if s, ok := obj["code"].(string); !ok || !code.MatchString(s) {
+			t.Errorf("seed %d: code %v doesn't match pattern", seed, obj["code"])
+		}
+
+		tags, ok := obj["tags"].([]interface{})
+		if !ok || len(tags) < 1 || len(tags) > 2 {
+			t.Errorf("seed %d: expected 1 or 2 tags, got: %v", seed, obj["tags"])
+		}
+	}
+}
+
+func TestSetSeed_VariesValues(t *testing.T) {
+	schema := seededSchema()
+
+	seen := make(map[interface{}]bool)
+	for seed := range int64(20) {
+		gen := &Generator{}
+		gen.SetSeed(seed)
+
+		obj := gen.generateExample(schema).(map[string]interface{})
+		seen[obj["status"]] = true
+	}
+
+	if len(seen) < 2 {
+		t.Errorf("expected different seeds to pick different enum members, got: %v", seen)
+	}
+}
+
+func TestBuildHTTPRequest_SeededIndependentOfOrder(t *testing.T) {
+	spec := &openapi3.T{}
+
+	schema := seededSchema()
+	newOp := func(path string) parser.Operation {
+		pathItem := &openapi3.PathItem{
+			Post: &openapi3.Operation{
+				RequestBody: &openapi3.RequestBodyRef{
+					Value: &openapi3.RequestBody{
+						Content: openapi3.Content{
+							"application/json": &openapi3.MediaType{Schema: &openapi3.SchemaRef{Value: schema}},
+						},
+					},
+				},
+			},
+		}
+		return parser.Operation{Path: path, Method: "POST", Operation: pathItem.Post, PathItem: pathItem}
+	}
+
+	gen := NewGenerator(spec)
+	gen.SetSeed(1)
+	before, _ := gen.BuildHTTPRequest(newOp("/a"))
+	gen.BuildHTTPRequest(newOp("/b"))
+	after, _ := gen.BuildHTTPRequest(newOp("/a"))
+
+	if before != after {
+		t.Errorf("expected the same request regardless of what was generated before, got:\n%s\nand:\n%s", before, after)
+	}
+}
+
+func TestBuildHTTPRequest_SeededSecurityIsReproducible(t *testing.T) {
+	spec := &openapi3.T{
+		Components: &openapi3.Components{
+			SecuritySchemes: openapi3.SecuritySchemes{
+				"key":    &openapi3.SecuritySchemeRef{Value: &openapi3.SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"}},
+				"sess":   &openapi3.SecuritySchemeRef{Value: &openapi3.SecurityScheme{Type: "apiKey", In: "cookie", Name: "SESSIONID"}},
+				"bearer": &openapi3.SecuritySchemeRef{Value: &openapi3.SecurityScheme{Type: "http", Scheme: "bearer"}},
+			},
+		},
+		Security: openapi3.SecurityRequirements{
+			{"key": []string{}, "sess": []string{}, "bearer": []string{}},
+		},
+	}
+
+	pathItem := &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			OperationID: "addPet",
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: &openapi3.RequestBody{
+					Content: openapi3.Content{
+						"application/json": &openapi3.MediaType{Schema: &openapi3.SchemaRef{Value: seededSchema()}},
+					},
+				},
+			},
+		},
+	}
+	op := parser.Operation{Path: "/pet", Method: "POST", Operation: pathItem.Post, PathItem: pathItem}
+
+	generate := func() string {
+		gen := NewGenerator(spec)
+		gen.SetSeed(1)
+		result, err := gen.BuildHTTPRequest(op)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	// map iteration order differs between runs, so a few tries are needed to catch it
+	expected := generate()
+	for range 20 {
+		if result := generate(); result != expected {
+			t.Fatalf("expected the same output for the same seed, got:\n%s\nand:\n%s", expected, result)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package generator
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// realisticString is a realistic value for string properties with a given name, format
+// is the string format the value conforms to, if any
+type realisticString struct {
+	value  string
+	format string
+}
+
+// realisticStrings maps property names, see lookupName, to realistic values.
+// Timestamps are fixed so the output stays the same between runs.
+var realisticStrings = map[string]realisticString{
+	// people
+	"firstname":    {value: "Jane"},
+	"givenname":    {value: "Jane"},
+	"lastname":     {value: "Doe"},
+	"surname":      {value: "Doe"},
+	"familyname":   {value: "Doe"},
+	"fullname":     {value: "Jane Doe"},
+	"name":         {value: "Jane Doe"},
+	"username":     {value: "jane.doe"},
+	"nickname":     {value: "jdoe"},
+	"email":        {value: "jane.doe@example.com", format: "email"},
+	"emailaddress": {value: "jane.doe@example.com", format: "email"},
+	"phone":        {value: "+15555550123", format: "phone"},
+	"phonenumber":  {value: "+15555550123", format: "phone"},
+	"mobile":       {value: "+15555550123", format: "phone"},
+	"password":     {value: "correct-horse-battery-staple", format: "password"},
+	"company":      {value: "Acme Inc."},
+	"organization": {value: "Acme Inc."},
+	"jobtitle":     {value: "Software Engineer"},
+
+	// addresses
+	"street":       {value: "221B Baker Street"},
+	"address":      {value: "221B Baker Street"},
+	"addressline1": {value: "221B Baker Street"},
+	"addressline2": {value: "Flat 2"},
+	"city":         {value: "London"},
+	"state":        {value: "Greater London"},
+	"region":       {value: "Greater London"},
+	"zip":          {value: "NW1 6XE"},
+	"zipcode":      {value: "NW1 6XE"},
+	"postcode":     {value: "NW1 6XE"},
+	"postalcode":   {value: "NW1 6XE"},
+	"country":      {value: "United Kingdom"},
+	"countrycode":  {value: "GB", format: "country-code"},
+
+	// text
+	"title":       {value: "An example title"},
+	"description": {value: "A short description of the resource."},
+	"summary":     {value: "A short summary."},
+	"comment":     {value: "Looks good to me."},
+	"message":     {value: "Hello, world!"},
+	"filename":    {value: "document.pdf"},
+	"slug":        {value: "an-example-title"},
+	"color":       {value: "blue"},
+	"currency":    {value: "EUR", format: "currency"},
+	"language":    {value: "en-US", format: "language"},
+	"locale":      {value: "en-US", format: "language"},
+	"timezone":    {value: "Europe/London"},
+
+	// web
+	"url":       {value: "https://example.com", format: "uri"},
+	"uri":       {value: "https://example.com", format: "uri"},
+	"website":   {value: "https://example.com", format: "uri"},
+	"homepage":  {value: "https://example.com", format: "uri"},
+	"link":      {value: "https://example.com", format: "uri"},
+	"imageurl":  {value: "https://example.com/image.png", format: "uri"},
+	"photourl":  {value: "https://example.com/photo.jpg", format: "uri"},
+	"avatar":    {value: "https://example.com/avatar.png", format: "uri"},
+	"domain":    {value: "example.com", format: "hostname"},
+	"hostname":  {value: "api.example.com", format: "hostname"},
+	"ipaddress": {value: "192.0.2.10", format: "ipv4"},
+
+	// dates and times
+	"createdat":   {value: "2024-05-14T09:30:00Z", format: "date-time"},
+	"updatedat":   {value: "2024-05-14T10:45:00Z", format: "date-time"},
+	"modifiedat":  {value: "2024-05-14T10:45:00Z", format: "date-time"},
+	"deletedat":   {value: "2024-05-14T11:00:00Z", format: "date-time"},
+	"timestamp":   {value: "2024-05-14T09:30:00Z", format: "date-time"},
+	"date":        {value: "2024-05-14", format: "date"},
+	"birthdate":   {value: "1990-04-12", format: "date"},
+	"dateofbirth": {value: "1990-04-12", format: "date"},
+}
+
+// realisticNumbers maps property names to realistic numeric values
+var realisticNumbers = map[string]float64{
+	"price":      19.99,
+	"amount":     19.99,
+	"cost":       19.99,
+	"total":      59.97,
+	"subtotal":   59.97,
+	"tax":        4.2,
+	"discount":   5,
+	"quantity":   3,
+	"qty":        3,
+	"count":      3,
+	"age":        34,
+	"year":       2024,
+	"month":      5,
+	"day":        14,
+	"rating":     4.5,
+	"score":      87,
+	"percentage": 25,
+	"percent":    25,
+	"latitude":   51.5237,
+	"lat":        51.5237,
+	"longitude":  -0.1585,
+	"lng":        -0.1585,
+	"lon":        -0.1585,
+	"weight":     2.5,
+	"height":     180,
+	"width":      640,
+	"page":       1,
+	"pagesize":   20,
+	"limit":      20,
+	"offset":     0,
+	"duration":   90,
+}
+
+// nameWords splits a property name in lower case words, on separators and camel case
+// boundaries, so firstName, first_name and first-name all become [first name]
+func nameWords(name string) []string {
+	var words []string
+	var current []rune
+	var prev rune
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+
+	for _, r := range name {
+		switch {
+		case r == '_' || r == '-' || r == ' ' || r == '.':
+			flush()
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			flush()
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+		prev = r
+	}
+	flush()
+
+	return words
+}
+
+// lookupName finds the value for a property name in table, which is keyed by joined
+// lower case words. The whole name is tried first and then ever shorter runs of its
+// trailing words, so billingCity uses city but capacity doesn't.
+func lookupName[T any](table map[string]T, name string) (T, bool) {
+	words := nameWords(name)
+	for i := range words {
+		if value, ok := table[strings.Join(words[i:], "")]; ok {
+			return value, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// singular makes a rough guess at the singular of a plural property name, which is
+// used as the name for the items of an array, e.g. photoUrls for photoUrl
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "ses"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
+
+// realisticStringFor returns a realistic value for the string property being generated,
+// as long as it is consistent with the schema's format and length constraints
+func (g *Generator) realisticStringFor(schema *openapi3.Schema) (string, bool) {
+	if g.property == "" {
+		return "", false
+	}
+
+	realistic, ok := lookupName(realisticStrings, g.property)
+	if !ok {
+		return "", false
+	}
+	if schema.Format != "" && schema.Format != realistic.format {
+		return "", false
+	}
+
+	length := uint64(utf8.RuneCountInString(realistic.value))
+	if length < schema.MinLength || (schema.MaxLength != nil && length > *schema.MaxLength) {
+		return "", false
+	}
+
+	return realistic.value, true
+}
+
+// realisticNumberFor returns a realistic value for the numeric property being generated,
+// as long as it satisfies the schema's bounds and multipleOf
+func (g *Generator) realisticNumberFor(schema *openapi3.Schema, integer bool) (float64, bool) {
+	if g.property == "" {
+		return 0, false
+	}
+
+	value, ok := lookupName(realisticNumbers, g.property)
+	if !ok {
+		return 0, false
+	}
+	if integer {
+		value = math.Round(value)
+	}
+
+	if !getNumericBounds(schema).contains(value) || !withinFormat(value, schema.Format) {
+		return 0, false
+	}
+	if m := schema.MultipleOf; m != nil && *m > 0 && !isMultipleOf(value, *m) {
+		return 0, false
+	}
+
+	return value, true
+}
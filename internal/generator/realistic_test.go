@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestNameWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{"firstName", []string{"first", "name"}},
+		{"first_name", []string{"first", "name"}},
+		{"billing-city", []string{"billing", "city"}},
+		{"imageURL", []string{"image", "url"}},
+		{"addressLine2", []string{"address", "line2"}},
+		{"capacity", []string{"capacity"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := nameWords(tt.name)
+
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("expected %v, got: %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateExample_Realistic(t *testing.T) {
+	gen := &Generator{Realistic: true}
+
+	maxLen := uint64(3)
+	minPrice := 100.0
+
+	schema := &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"firstName":   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+			"billingCity": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+			"capacity":    &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+			"createdAt":   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "date-time"}},
+			"email":       &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "uuid"}},
+			"country":     &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, MaxLength: &maxLen}},
+			"price":       &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"number"}}},
+			"quantity":    &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}},
+			"total":       &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"number"}, Min: &minPrice}},
+			"lastName":    &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Example: "Smith"}},
+			"photoUrls": &openapi3.SchemaRef{
+				Value: &openapi3.Schema{
+					Type:  &openapi3.Types{"array"},
+					Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+				},
+			},
+		},
+	}
+
+	obj, ok := gen.generateExample(schema).(map[string]interface{})
+	if !ok {
+		t.Fatal("expected object")
+	}
+
+	expected := map[string]interface{}{
+		"firstName":   "Jane",
+		"billingCity": "London",
+		"capacity":    "string",
+		"createdAt":   "2024-05-14T09:30:00Z",
+		"email":       formatExamples["uuid"], // format doesn't match the realistic value
+		"country":     "str",                  // realistic value is too long
+		"price":       19.99,
+		"quantity":    3,
+		"total":       100.0, // realistic value is below the minimum
+		"lastName":    "Smith",
+	}
+
+	for name, value := range expected {
+		if obj[name] != value {
+			t.Errorf("expected %s to be %v, got: %v", name, value, obj[name])
+		}
+	}
+
+	photoUrls, ok := obj["photoUrls"].([]interface{})
+	if !ok || len(photoUrls) != 1 || photoUrls[0] != "https://example.com/photo.jpg" {
+		t.Errorf("expected realistic photo url items, got: %v", obj["photoUrls"])
+	}
+}
+
+func TestGenerateExample_RealisticDisabled(t *testing.T) {
+	gen := &Generator{}
+
+	schema := &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"firstName": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
+		},
+	}
+
+	obj, ok := gen.generateExample(schema).(map[string]interface{})
+	if !ok {
+		t.Fatal("expected object")
+	}
+
+	if obj["firstName"] != "string" {
+		t.Errorf("expected plain string without realistic mode, got: %v", obj["firstName"])
+	}
+}
@@ -0,0 +1,244 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kalli/openapi-http/internal/parser"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// Body is a request body to serialize
+type Body struct {
+	Operation   parser.Operation
+	ContentType string // media type as given in the spec, including any parameters
+	MediaType   *openapi3.MediaType
+	Example     any // explicit or generated example, nil if there is none
+}
+
+// Serializer serializes the example of a request body in its media type
+type Serializer func(body Body) (string, error)
+
+// serializerEntry is a Serializer and the media type pattern it is registered for
+type serializerEntry struct {
+	pattern   string
+	serialize Serializer
+}
+
+// RegisterSerializer sets the serializer for request bodies with a media type matching
+// pattern. Patterns are media types, with * for any type or subtype and */*+json for any
+// media type with a structured syntax suffix. The most specific pattern wins, and of equally
+// specific ones the last registered, before the built-in serializers.
+func (g *Generator) RegisterSerializer(pattern string, serializer Serializer) {
+	g.serializers = append(g.serializers, serializerEntry{pattern: strings.ToLower(pattern), serialize: serializer})
+}
+
+// builtinSerializers are the serializers for the media types supported out of the box
+func (g *Generator) builtinSerializers() []serializerEntry {
+	xmlSerializer := func(b Body) (string, error) { return encodeXML(b.Example, b.MediaType.Schema), nil }
+	yamlSerializer := Serializer(serializeYAML)
+	ndjsonSerializer := Serializer(serializeNDJSON)
+	fileSerializer := func(b Body) (string, error) {
+		return g.fixtureRef(fileName(operationName(b.Operation), b.ContentType)), nil
+	}
+
+	return []serializerEntry{
+		{"application/json", serializeJSON},
+		{"*/*+json", serializeJSON},
+		{"application/xml", xmlSerializer},
+		{"text/xml", xmlSerializer},
+		{"*/*+xml", xmlSerializer},
+		{"application/yaml", yamlSerializer},
+		{"application/x-yaml", yamlSerializer},
+		{"text/yaml", yamlSerializer},
+		{"*/*+yaml", yamlSerializer},
+		{"application/x-ndjson", ndjsonSerializer},
+		{"application/ndjson", ndjsonSerializer},
+		{"text/plain", serializeText},
+		{"text/csv", serializeCSV},
+		{"application/x-www-form-urlencoded", func(b Body) (string, error) {
+			return encodeForm(b.Example, b.MediaType.Encoding), nil
+		}},
+		{"multipart/form-data", func(b Body) (string, error) {
+			return g.encodeMultipart(b.Example, b.MediaType), nil
+		}},
+		{"application/octet-stream", fileSerializer},
+		{"application/pdf", fileSerializer},
+		{"application/zip", fileSerializer},
+		{"image/*", fileSerializer},
+		{"audio/*", fileSerializer},
+		{"video/*", fileSerializer},
+	}
+}
+
+// serializerFor finds the serializer for a media type, media types without one are sent
+// as JSON
+func (g *Generator) serializerFor(mediaType string) Serializer {
+	candidates := slices.Clone(g.serializers)
+	slices.Reverse(candidates)
+	candidates = append(candidates, g.builtinSerializers()...)
+
+	serializer, best := Serializer(serializeJSON), -1
+	for _, entry := range candidates {
+		if specificity, ok := matchMediaType(entry.pattern, mediaType); ok && specificity > best {
+			serializer, best = entry.serialize, specificity
+		}
+	}
+	return serializer
+}
+
+// matchMediaType checks whether a media type matches a pattern, ignoring parameters, and
+// how specific the match is: 3 for the exact media type, 2 for a structured syntax suffix
+// like */*+json, 1 for a subtype wildcard like image/* and 0 for */*
+func matchMediaType(pattern, mediaType string) (int, bool) {
+	patternType, patternSubtype, _ := strings.Cut(baseMediaType(pattern), "/")
+	mediaTypeType, mediaSubtype, _ := strings.Cut(baseMediaType(mediaType), "/")
+
+	if patternType != "*" && patternType != mediaTypeType {
+		return 0, false
+	}
+
+	switch {
+	case patternSubtype == mediaSubtype:
+		if patternType == "*" {
+			return 1, true
+		}
+		return 3, true
+	case strings.HasPrefix(patternSubtype, "*+"):
+		return 2, strings.HasSuffix(mediaSubtype, patternSubtype[1:])
+	case patternSubtype == "*":
+		if patternType == "*" {
+			return 0, true
+		}
+		return 1, true
+	}
+	return 0, false
+}
+
+// baseMediaType returns a media type without parameters, in lower case
+func baseMediaType(mediaType string) string {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	return strings.TrimSpace(strings.ToLower(mediaType))
+}
+
+// isJSONMediaType checks whether a media type is JSON, including +json types like
+// application/problem+json
+func isJSONMediaType(mediaType string) bool {
+	_, exact := matchMediaType("application/json", mediaType)
+	_, suffix := matchMediaType("*/*+json", mediaType)
+	return exact || suffix
+}
+
+// serializeJSON formats the example as indented JSON
+func serializeJSON(b Body) (string, error) {
+	if b.Example == nil {
+		return "{}", nil
+	}
+
+	data, err := json.MarshalIndent(b.Example, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// serializeYAML formats the example as YAML
+func serializeYAML(b Body) (string, error) {
+	if b.Example == nil {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(b.Example); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// serializeNDJSON formats the example as newline delimited JSON, with a line per item
+// of an array
+func serializeNDJSON(b Body) (string, error) {
+	if s, ok := b.Example.(string); ok {
+		// already serialized
+		return s, nil
+	}
+
+	items, ok := b.Example.([]any)
+	if !ok {
+		items = []any{b.Example}
+	}
+
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, string(data))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// serializeText formats the example as plain text, objects and arrays as JSON
+func serializeText(b Body) (string, error) {
+	return scalarString(b.Example), nil
+}
+
+// serializeCSV formats the example as CSV. Objects are rows, with a header row of all
+// their properties, other values are a row with a single column.
+func serializeCSV(b Body) (string, error) {
+	if s, ok := b.Example.(string); ok {
+		// already serialized
+		return s, nil
+	}
+
+	items, ok := b.Example.([]any)
+	if !ok {
+		items = []any{b.Example}
+	}
+
+	columns := make(map[string]bool)
+	for _, item := range items {
+		if obj, ok := item.(map[string]any); ok {
+			for k := range obj {
+				columns[k] = true
+			}
+		}
+	}
+	header := slices.Sorted(maps.Keys(columns))
+
+	var records [][]string
+	if len(header) > 0 {
+		records = append(records, header)
+	}
+	for _, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			records = append(records, []string{scalarString(item)})
+			continue
+		}
+		record := make([]string, len(header))
+		for i, column := range header {
+			record[i] = scalarString(obj[column])
+		}
+		records = append(records, record)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
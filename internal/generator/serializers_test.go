@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/kalli/openapi-http/internal/parser"
+)
+
+func TestMatchMediaType(t *testing.T) {
+	tests := []struct {
+		pattern     string
+		mediaType   string
+		specificity int
+		matches     bool
+	}{
+		{"application/json", "application/json", 3, true},
+		{"application/json", "Application/JSON; charset=utf-8", 3, true},
+		{"application/json", "application/xml", 0, false},
+		{"*/*+json", "application/merge-patch+json", 2, true},
+		{"*/*+json", "application/vnd.api+json; ext=bulk", 2, true},
+		{"*/*+json", "application/json", 0, false},
+		{"application/*+xml", "application/atom+xml", 2, true},
+		{"application/*+xml", "image/svg+xml", 0, false},
+		{"image/*", "image/png", 1, true},
+		{"image/*", "text/plain", 0, false},
+		{"*/*", "text/plain", 0, true},
+	}
+
+	for _, tt := range tests {
+		specificity, matches := matchMediaType(tt.pattern, tt.mediaType)
+		if matches != tt.matches || (matches && specificity != tt.specificity) {
+			t.Errorf("matchMediaType(%q, %q): expected %d, %v, got %d, %v", tt.pattern, tt.mediaType, tt.specificity, tt.matches, specificity, matches)
+		}
+	}
+}
+
+func TestSerializers(t *testing.T) {
+	schema := &openapi3.SchemaRef{
+		Ref:   "#/components/schemas/Pet",
+		Value: &openapi3.Schema{Type: &openapi3.Types{"object"}},
+	}
+	pet := map[string]any{"name": "Rex", "tags": []any{"dog"}}
+	pets := []any{
+		map[string]any{"name": "Rex", "age": 3},
+		map[string]any{"name": "Tom, the cat"},
+	}
+
+	tests := []struct {
+		contentType string
+		example     any
+		expected    string
+	}{
+		{"application/json", pet, "{\n  \"name\": \"Rex\",\n  \"tags\": [\n    \"dog\"\n  ]\n}"},
+		{"application/json; charset=utf-8", pet, "{\n  \"name\": \"Rex\",\n  \"tags\": [\n    \"dog\"\n  ]\n}"},
+		{"application/merge-patch+json", map[string]any{"name": "Rex"}, "{\n  \"name\": \"Rex\"\n}"},
+		{"application/problem+json", nil, "{}"},
+		{"application/vnd.custom", map[string]any{"name": "Rex"}, "{\n  \"name\": \"Rex\"\n}"},
+		{"application/atom+xml", map[string]any{"name": "Rex"}, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<Pet>\n  <name>Rex</name>\n</Pet>"},
+		{"application/yaml", pet, "name: Rex\ntags:\n  - dog"},
+		{"text/plain", "Hello", "Hello"},
+		{"text/plain", 42, "42"},
+		{"text/csv", pets, "age,name\n3,Rex\n,\"Tom, the cat\""},
+		{"text/csv", []any{"a", "b"}, "a\nb"},
+		{"application/x-ndjson", pets, "{\"age\":3,\"name\":\"Rex\"}\n{\"name\":\"Tom, the cat\"}"},
+		{"application/x-ndjson", map[string]any{"name": "Rex"}, "{\"name\":\"Rex\"}"},
+		{"image/png", nil, "< ./fixtures/addPet.png"},
+	}
+
+	gen := NewGenerator(&openapi3.T{})
+	op := parser.Operation{Operation: &openapi3.Operation{OperationID: "addPet"}}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			body := Body{Operation: op, ContentType: tt.contentType, MediaType: &openapi3.MediaType{Schema: schema}, Example: tt.example}
+			result, err := gen.serializerFor(tt.contentType)(body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRegisterSerializer(t *testing.T) {
+	constant := func(s string) Serializer {
+		return func(Body) (string, error) { return s, nil }
+	}
+
+	gen := NewGenerator(&openapi3.T{})
+	gen.RegisterSerializer("application/*", constant("any application"))
+	gen.RegisterSerializer("application/vnd.api+json", constant("json:api"))
+	gen.RegisterSerializer("text/csv", constant("first csv"))
+	gen.RegisterSerializer("text/csv", constant("second csv"))
+
+	tests := []struct {
+		contentType string
+		expected    string
+	}{
+		// more specific built-in patterns still win
+		{"application/json", "{}"},
+		{"application/vnd.api+json", "json:api"},
+		{"application/vnd.custom", "any application"},
+		{"text/csv", "second csv"},
+	}
+
+	for _, tt := range tests {
+		result, err := gen.serializerFor(tt.contentType)(Body{ContentType: tt.contentType, MediaType: &openapi3.MediaType{}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.contentType, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// maxPatternRepeats caps how often unbounded quantifiers like * and + are repeated
+// while looking for a string that also satisfies the length bounds
+const maxPatternRepeats = 64
+
+// generateString creates an example string satisfying the schema's pattern, format
+// and length constraints
+func (g *Generator) generateString(schema *openapi3.Schema) string {
+	if schema.Pattern != "" {
+		var intn func(int) int
+		if g.rand != nil {
+			intn = g.intn
+		}
+		if s, err := stringFromPattern(schema.Pattern, schema.MinLength, schema.MaxLength, intn); err == nil {
+			return s
+		}
+		// patterns Go can't parse (e.g. lookaheads) fall back to the regular generation
+	}
+
+	if g.Realistic {
+		if s, ok := g.realisticStringFor(schema); ok {
+			return s
+		}
+	}
+
+	if example, ok := g.formatExample(schema.Format); ok {
+		return example
+	}
+
+	return fitLength("string", schema.MinLength, schema.MaxLength)
+}
+
+// fitLength repeats or truncates s so its length, in characters, is within the bounds
+func fitLength(s string, minLen uint64, maxLen *uint64) string {
+	if s == "" {
+		s = "x"
+	}
+
+	runes := []rune(s)
+	for uint64(len(runes)) < minLen {
+		runes = append(runes, []rune(s)...)
+	}
+	if maxLen != nil && uint64(len(runes)) > *maxLen {
+		runes = runes[:*maxLen]
+	}
+
+	return string(runes)
+}
+
+// stringFromPattern generates a string matching the regular expression pattern. Quantifiers
+// are repeated as few times as possible, starting at once, while trying to satisfy the length
+// bounds. When no string fits the bounds the first one matching the pattern is returned.
+// Characters and alternatives are picked at random with intn, if set.
+func stringFromPattern(pattern string, minLen uint64, maxLen *uint64, intn func(int) int) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+	matcher, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	var fallback string
+	var matched bool
+	for _, reps := range repeatCandidates() {
+		var sb strings.Builder
+		if err := writePattern(&sb, re, reps, intn); err != nil {
+			return "", err
+		}
+
+		s := sb.String()
+		if !matcher.MatchString(s) {
+			continue
+		}
+		if !matched {
+			fallback, matched = s, true
+		}
+
+		length := uint64(utf8.RuneCountInString(s))
+		if length >= minLen && (maxLen == nil || length <= *maxLen) {
+			return s, nil
+		}
+	}
+
+	if !matched {
+		return "", fmt.Errorf("could not generate a string for pattern %q", pattern)
+	}
+	return fallback, nil
+}
+
+// repeatCandidates lists the repeat counts to try, once first as that gives the most
+// natural looking strings
+func repeatCandidates() []int {
+	candidates := []int{1, 0}
+	for i := 2; i <= maxPatternRepeats; i++ {
+		candidates = append(candidates, i)
+	}
+	return candidates
+}
+
+// writePattern writes a string matching re, repeating quantified expressions reps
+// times where their bounds allow it
+func writePattern(sb *strings.Builder, re *syntax.Regexp, reps int, intn func(int) int) error {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return fmt.Errorf("pattern can never match")
+
+	case syntax.OpLiteral:
+		sb.WriteString(string(re.Rune))
+
+	case syntax.OpCharClass:
+		sb.WriteRune(pickRune(re.Rune, intn))
+
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sb.WriteRune('a')
+
+	case syntax.OpCapture:
+		return writePattern(sb, re.Sub[0], reps, intn)
+
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		minCount, maxCount := repeatBounds(re)
+		count := max(reps, minCount)
+		if maxCount >= 0 {
+			count = min(count, maxCount)
+		}
+		for range count {
+			if err := writePattern(sb, re.Sub[0], reps, intn); err != nil {
+				return err
+			}
+		}
+
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := writePattern(sb, sub, reps, intn); err != nil {
+				return err
+			}
+		}
+
+	case syntax.OpAlternate:
+		sub := re.Sub[0]
+		if intn != nil {
+			sub = re.Sub[intn(len(re.Sub))]
+		}
+		return writePattern(sb, sub, reps, intn)
+
+	default:
+		// anchors, word boundaries and empty matches don't produce any characters
+	}
+
+	return nil
+}
+
+// repeatBounds returns the minimum and maximum repetitions of a quantifier, the
+// maximum is -1 when unbounded
+func repeatBounds(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpStar:
+		return 0, -1
+	case syntax.OpPlus:
+		return 1, -1
+	case syntax.OpQuest:
+		return 0, 1
+	default:
+		return re.Min, re.Max
+	}
+}
+
+// pickRune picks a readable character from a character class, given as pairs of
+// inclusive rune ranges. With intn set a random printable ASCII character is picked.
+func pickRune(ranges []rune, intn func(int) int) rune {
+	if intn != nil {
+		var printable []rune
+		for i := 0; i+1 < len(ranges); i += 2 {
+			for r := max(ranges[i], '!'); r <= min(ranges[i+1], '~'); r++ {
+				printable = append(printable, r)
+			}
+		}
+		if len(printable) > 0 {
+			return printable[intn(len(printable))]
+		}
+	}
+
+	inClass := func(r rune) bool {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			if r >= ranges[i] && r <= ranges[i+1] {
+				return true
+			}
+		}
+		return false
+	}
+
+	// prefer letters and digits
+	for _, r := range "aA0" {
+		if inClass(r) {
+			return r
+		}
+	}
+
+	// otherwise the first printable character
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for r := ranges[i]; r <= ranges[i+1] && r-ranges[i] < 256; r++ {
+			if unicode.IsGraphic(r) && !unicode.IsSpace(r) {
+				return r
+			}
+		}
+	}
+
+	if len(ranges) > 0 {
+		return ranges[0]
+	}
+	return 'a'
+}
@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerateExample_StringLength(t *testing.T) {
+	gen := &Generator{}
+
+	maxThree := uint64(3)
+	maxTwelve := uint64(12)
+
+	tests := []struct {
+		name     string
+		minLen   uint64
+		maxLen   *uint64
+		expected string
+	}{
+		{"no bounds", 0, nil, "string"},
+		{"min length", 10, nil, "stringstring"},
+		{"max length", 0, &maxThree, "str"},
+		{"min and max length", 8, &maxTwelve, "stringstring"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := &openapi3.Schema{
+				Type:      &openapi3.Types{"string"},
+				MinLength: tt.minLen,
+				MaxLength: tt.maxLen,
+			}
+
+			result := gen.generateExample(schema)
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got: %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateExample_StringPattern(t *testing.T) {
+	gen := &Generator{}
+
+	maxFive := uint64(5)
+
+	tests := []struct {
+		name     string
+		pattern  string
+		minLen   uint64
+		maxLen   *uint64
+		expected string
+	}{
+		{"fixed repeats", `^[A-Z]{3}-\d{4}$`, 0, nil, "AAA-0000"},
+		{"alternation", `^(cat|dog)s?$`, 0, nil, "cats"},
+		{"plus with min length", `^[a-z]+$`, 4, nil, "aaaa"},
+		{"star with max length", `^x[0-9]*$`, 0, &maxFive, "x0"},
+		{"unanchored", `abc`, 0, nil, "abc"},
+		{"negated class", `^[^"]+$`, 0, nil, "a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := &openapi3.Schema{
+				Type:      &openapi3.Types{"string"},
+				Pattern:   tt.pattern,
+				MinLength: tt.minLen,
+				MaxLength: tt.maxLen,
+			}
+
+			result, ok := gen.generateExample(schema).(string)
+			if !ok {
+				t.Fatalf("expected string, got: %T", result)
+			}
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got: %q", tt.expected, result)
+			}
+
+			if !regexp.MustCompile(tt.pattern).MatchString(result) {
+				t.Errorf("%q does not match pattern %s", result, tt.pattern)
+			}
+		})
+	}
+}
+
+func TestGenerateExample_UnsupportedPattern(t *testing.T) {
+	gen := &Generator{}
+
+	// lookaheads aren't supported by Go's regexp, fall back to a plain string
+	schema := &openapi3.Schema{
+		Type:    &openapi3.Types{"string"},
+		Pattern: `^(?=.*\d).+$`,
+	}
+
+	result := gen.generateExample(schema)
+
+	if result != "string" {
+		t.Errorf("expected fallback 'string', got: %v", result)
+	}
+}
@@ -0,0 +1,201 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// serializeStyle serializes a named value as name=value pairs following an OpenAPI
+// serialization style, as used by query parameters and form bodies. Values are
+// percent-encoded, leaving reserved characters as they are when allowReserved is set.
+// Nested arrays and objects, which the styles don't cover, are serialized as JSON.
+func serializeStyle(name string, value any, sm *openapi3.SerializationMethod, allowReserved bool) []string {
+	escape := func(s string) string { return escapeValue(s, allowReserved) }
+	pair := func(key, value string) string { return escapeValue(key, false) + "=" + escape(value) }
+
+	switch v := value.(type) {
+	case []any:
+		if sm.Explode {
+			pairs := make([]string, 0, len(v))
+			for _, item := range v {
+				pairs = append(pairs, pair(name, scalarString(item)))
+			}
+			return pairs
+		}
+
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, escape(scalarString(item)))
+		}
+		return []string{escapeValue(name, false) + "=" + strings.Join(items, styleDelimiter(sm.Style))}
+
+	case map[string]any:
+		keys := slices.Sorted(maps.Keys(v))
+
+		if sm.Style == openapi3.SerializationDeepObject {
+			var pairs []string
+			for _, k := range keys {
+				pairs = append(pairs, deepObjectPairs(escapeValue(name, false)+"["+escapeValue(k, false)+"]", v[k], escape)...)
+			}
+			return pairs
+		}
+
+		if sm.Explode {
+			pairs := make([]string, 0, len(keys))
+			for _, k := range keys {
+				pairs = append(pairs, pair(k, scalarString(v[k])))
+			}
+			return pairs
+		}
+
+		items := make([]string, 0, 2*len(keys))
+		for _, k := range keys {
+			items = append(items, escape(k), escape(scalarString(v[k])))
+		}
+		return []string{escapeValue(name, false) + "=" + strings.Join(items, styleDelimiter(sm.Style))}
+	}
+
+	return []string{pair(name, scalarString(value))}
+}
+
+// styleDelimiter is what separates the items of arrays and objects that aren't exploded:
+// an encoded space for spaceDelimited, a pipe for pipeDelimited, otherwise a comma
+func styleDelimiter(style string) string {
+	switch style {
+	case openapi3.SerializationSpaceDelimited:
+		return "%20"
+	case openapi3.SerializationPipeDelimited:
+		return "|"
+	}
+	return ","
+}
+
+// serializePathStyle serializes a path parameter value following an OpenAPI serialization
+// style: simple (1,2), label (.1.2 with explode, .1,2 without) or matrix (;id=1;id=2 with
+// explode, ;id=1,2 without). Objects are key,value lists, or key=value with explode.
+// Names, keys and values are percent-encoded, so they stay within their path segment.
+func serializePathStyle(name string, value any, sm *openapi3.SerializationMethod) string {
+	escape := func(s string) string { return escapeValue(s, false) }
+	scalar := func(v any) string { return escape(scalarString(v)) }
+	name = escape(name)
+
+	prefix, sep := "", ","
+	switch sm.Style {
+	case openapi3.SerializationLabel:
+		prefix = "."
+		if sm.Explode {
+			sep = "."
+		}
+	case openapi3.SerializationMatrix:
+		prefix = ";"
+		if sm.Explode {
+			sep = ";"
+		}
+	}
+
+	var items []string
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			if sm.Style == openapi3.SerializationMatrix && sm.Explode {
+				items = append(items, name+"="+scalar(item))
+			} else {
+				items = append(items, scalar(item))
+			}
+		}
+		if sm.Style == openapi3.SerializationMatrix && !sm.Explode {
+			return prefix + name + "=" + strings.Join(items, sep)
+		}
+
+	case map[string]any:
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			if sm.Explode {
+				items = append(items, escape(k)+"="+scalar(v[k]))
+			} else {
+				items = append(items, escape(k), scalar(v[k]))
+			}
+		}
+		if sm.Style == openapi3.SerializationMatrix && !sm.Explode {
+			return prefix + name + "=" + strings.Join(items, sep)
+		}
+
+	default:
+		if sm.Style == openapi3.SerializationMatrix {
+			return prefix + name + "=" + scalar(v)
+		}
+		return prefix + scalar(v)
+	}
+
+	return prefix + strings.Join(items, sep)
+}
+
+// deepObjectPairs serializes a deepObject value, nesting objects as key[a][b]=value
+func deepObjectPairs(key string, value any, escape func(string) string) []string {
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return []string{key + "=" + escape(scalarString(value))}
+	}
+
+	var pairs []string
+	for _, k := range slices.Sorted(maps.Keys(obj)) {
+		pairs = append(pairs, deepObjectPairs(key+"["+escapeValue(k, false)+"]", obj[k], escape)...)
+	}
+	return pairs
+}
+
+// scalarString formats a single value for use in a URL or form, without the exponent
+// JSON would use for large numbers. Arrays and objects are formatted as JSON.
+func scalarString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// escapeValue percent-encodes everything but the unreserved characters of RFC 3986, and
+// the reserved ones as well when allowReserved is set. {{variable}} placeholders are left
+// as they are, so .http clients can still substitute them.
+func escapeValue(s string, allowReserved bool) string {
+	const reserved = ":/?#[]@!$&'()*+,;="
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if strings.HasPrefix(s[i:], "{{") {
+			if end := strings.Index(s[i+2:], "}}"); end >= 0 {
+				sb.WriteString(s[i : i+2+end+2])
+				i += 2 + end + 1
+				continue
+			}
+		}
+
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			sb.WriteByte(c)
+		case allowReserved && strings.IndexByte(reserved, c) >= 0:
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
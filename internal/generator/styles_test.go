@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestSerializeStyle(t *testing.T) {
+	array := []any{"blue", "black", "brown"}
+	object := map[string]any{"R": 100, "G": 200, "B": 150}
+
+	tests := []struct {
+		name     string
+		value    any
+		style    string
+		explode  bool
+		expected string
+	}{
+		{"primitive", "blue", openapi3.SerializationForm, true, "color=blue"},
+		{"number", 1234567.5, openapi3.SerializationForm, true, "color=1234567.5"},
+		{"form array", array, openapi3.SerializationForm, false, "color=blue,black,brown"},
+		{"form array exploded", array, openapi3.SerializationForm, true, "color=blue&color=black&color=brown"},
+		{"space delimited array", array, openapi3.SerializationSpaceDelimited, false, "color=blue%20black%20brown"},
+		{"pipe delimited array", array, openapi3.SerializationPipeDelimited, false, "color=blue|black|brown"},
+		{"form object", object, openapi3.SerializationForm, false, "color=B,150,G,200,R,100"},
+		{"form object exploded", object, openapi3.SerializationForm, true, "B=150&G=200&R=100"},
+		{"space delimited object", object, openapi3.SerializationSpaceDelimited, false, "color=B%20150%20G%20200%20R%20100"},
+		{"pipe delimited object", object, openapi3.SerializationPipeDelimited, false, "color=B|150|G|200|R|100"},
+		{"deep object", object, openapi3.SerializationDeepObject, true, "color[B]=150&color[G]=200&color[R]=100"},
+		{"nested deep object", map[string]any{"rgb": map[string]any{"R": 100}}, openapi3.SerializationDeepObject, true, "color[rgb][R]=100"},
+		{"nested array", []any{[]any{1, 2}}, openapi3.SerializationForm, true, "color=%5B1%2C2%5D"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := &openapi3.SerializationMethod{Style: tt.style, Explode: tt.explode}
+			result := strings.Join(serializeStyle("color", tt.value, sm, false), "&")
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSerializePathStyle(t *testing.T) {
+	array := []any{"blue", "black", "brown"}
+	object := map[string]any{"R": 100, "G": 200, "B": 150}
+
+	tests := []struct {
+		name     string
+		value    any
+		style    string
+		explode  bool
+		expected string
+	}{
+		{"simple primitive", "blue", openapi3.SerializationSimple, false, "blue"},
+		{"simple array", array, openapi3.SerializationSimple, false, "blue,black,brown"},
+		{"simple array exploded", array, openapi3.SerializationSimple, true, "blue,black,brown"},
+		{"simple object", object, openapi3.SerializationSimple, false, "B,150,G,200,R,100"},
+		{"simple object exploded", object, openapi3.SerializationSimple, true, "B=150,G=200,R=100"},
+		{"label primitive", "blue", openapi3.SerializationLabel, false, ".blue"},
+		{"label array", array, openapi3.SerializationLabel, false, ".blue,black,brown"},
+		{"label array exploded", array, openapi3.SerializationLabel, true, ".blue.black.brown"},
+		{"label object", object, openapi3.SerializationLabel, false, ".B,150,G,200,R,100"},
+		{"label object exploded", object, openapi3.SerializationLabel, true, ".B=150.G=200.R=100"},
+		{"matrix primitive", "blue", openapi3.SerializationMatrix, false, ";color=blue"},
+		{"matrix array", array, openapi3.SerializationMatrix, false, ";color=blue,black,brown"},
+		{"matrix array exploded", array, openapi3.SerializationMatrix, true, ";color=blue;color=black;color=brown"},
+		{"matrix object", object, openapi3.SerializationMatrix, false, ";color=B,150,G,200,R,100"},
+		{"matrix object exploded", object, openapi3.SerializationMatrix, true, ";B=150;G=200;R=100"},
+		{"number", 1234567.5, openapi3.SerializationSimple, false, "1234567.5"},
+		{"escaped", "a/b c,ü", openapi3.SerializationSimple, false, "a%2Fb%20c%2C%C3%BC"},
+		{"escaped keys", map[string]any{"a b": "c;d"}, openapi3.SerializationMatrix, true, ";a%20b=c%3Bd"},
+		{"placeholder", "{{petId}}", openapi3.SerializationLabel, false, ".{{petId}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := &openapi3.SerializationMethod{Style: tt.style, Explode: tt.explode}
+			if result := serializePathStyle("color", tt.value, sm); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestEscapeValue(t *testing.T) {
+	tests := []struct {
+		value         string
+		allowReserved bool
+		expected      string
+	}{
+		{"plain-text_1.0~", false, "plain-text_1.0~"},
+		{"a b&c=d", false, "a%20b%26c%3Dd"},
+		{"https://example.com/?q=1", false, "https%3A%2F%2Fexample.com%2F%3Fq%3D1"},
+		{"https://example.com/?q=1", true, "https://example.com/?q=1"},
+		{"a b", true, "a%20b"},
+		{"é", false, "%C3%A9"},
+		{"{{token}}", false, "{{token}}"},
+		{"Bearer {{token}}/x", false, "Bearer%20{{token}}%2Fx"},
+		{"{{unclosed", false, "%7B%7Bunclosed"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeValue(tt.value, tt.allowReserved); got != tt.expected {
+			t.Errorf("escapeValue(%q, %v): expected %q, got %q", tt.value, tt.allowReserved, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package generator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kalli/openapi-http/internal/parser"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// contentTypeVariant is a media type of a request body and the suffix for the name of
+// its request, empty for the preferred media type
+type contentTypeVariant struct {
+	contentType string
+	suffix      string
+}
+
+// contentTypeVariants lists the media types to generate requests for: only the preferred
+// one, or with EachContentType all media types of the request body
+func (g *Generator) contentTypeVariants(op parser.Operation) []contentTypeVariant {
+	rb := op.Operation.RequestBody
+	if !g.EachContentType || rb == nil || rb.Value == nil || len(rb.Value.Content) < 2 {
+		return []contentTypeVariant{{}}
+	}
+	return mediaTypeVariants(rb.Value.Content)
+}
+
+// mediaTypeVariants lists the media types of a request body in alphabetical order, with
+// a suffix based on their subtype, e.g. xml for application/xml, or on the whole media type
+// when the subtype is not unique
+func mediaTypeVariants(content openapi3.Content) []contentTypeVariant {
+	contentTypes := slices.Sorted(maps.Keys(content))
+
+	suffixes := make(map[string]int)
+	for _, contentType := range contentTypes {
+		suffixes[mediaTypeSuffix(contentType, false)]++
+	}
+
+	variants := make([]contentTypeVariant, 0, len(contentTypes))
+	for _, contentType := range contentTypes {
+		suffix := mediaTypeSuffix(contentType, false)
+		if suffixes[suffix] > 1 {
+			suffix = mediaTypeSuffix(contentType, true)
+		}
+		variants = append(variants, contentTypeVariant{contentType: contentType, suffix: suffix})
+	}
+	return variants
+}
+
+// mediaTypeSuffix turns a media type in a suffix for request names, from its subtype without
+// x- and vnd. prefixes or from the whole media type when full is set
+func mediaTypeSuffix(mediaType string, full bool) string {
+	base := baseMediaType(mediaType)
+	if !full {
+		_, subtype, _ := strings.Cut(base, "/")
+		switch subtype {
+		case "x-www-form-urlencoded":
+			return "form"
+		case "form-data":
+			return "multipart"
+		}
+		base = strings.TrimPrefix(strings.TrimPrefix(subtype, "x-"), "vnd.")
+	}
+	return strings.Trim(nonWord.ReplaceAllString(base, "_"), "_")
+}
+
+// exampleVariant is a named example to generate a request with, the suffix for the name
+// of its request and its summary
+type exampleVariant struct {
+	name    string
+	suffix  string
+	summary string
+}
+
+// exampleVariants lists the named examples to generate requests for, across the request
+// body and the parameters: the one selected with Example, or else all of them, in alphabetical
+// order. Only when there are several the names of their requests get a suffix. Selecting an
+// example the operation doesn't define is an error, unless it has no named examples at all.
+func (g *Generator) exampleVariants(op parser.Operation) ([]exampleVariant, error) {
+	sources := g.exampleSources(op)
+
+	names := make(map[string]bool)
+	for _, examples := range sources {
+		for name := range examples {
+			names[name] = true
+		}
+	}
+	if len(names) == 0 {
+		return []exampleVariant{{}}, nil
+	}
+
+	if g.Example != "" {
+		if !names[g.Example] {
+			return nil, fmt.Errorf("example %q not found, expected one of %s", g.Example, strings.Join(slices.Sorted(maps.Keys(names)), ", "))
+		}
+		return []exampleVariant{{name: g.Example, summary: exampleSummary(sources, g.Example)}}, nil
+	}
+
+	variants := make([]exampleVariant, 0, len(names))
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		variant := exampleVariant{name: name, summary: exampleSummary(sources, name)}
+		if len(names) > 1 {
+			variant.suffix = strings.Trim(nonWord.ReplaceAllString(name, "_"), "_")
+		}
+		variants = append(variants, variant)
+	}
+	return variants, nil
+}
+
+// exampleSources collects the named examples of the request body, in the media type it
+// is sent as, and of the parameters
+func (g *Generator) exampleSources(op parser.Operation) []openapi3.Examples {
+	var sources []openapi3.Examples
+
+	// explicit examples aren't used for minimal bodies, nor for placeholders
+	if rb := op.Operation.RequestBody; rb != nil && rb.Value != nil && g.Body != BodyRequired && g.Body != BodyNone {
+		if _, mediaType := g.requestMediaType(rb.Value); mediaType != nil && mediaType.Example == nil {
+			sources = append(sources, mediaType.Examples)
+		}
+	}
+
+	for _, in := range []string{"path", "query", "header", "cookie"} {
+		for _, param := range g.collectParameters(op, in) {
+			if param.Example == nil {
+				sources = append(sources, param.Examples)
+			}
+		}
+	}
+
+	return sources
+}
+
+// exampleSummary finds the summary of a named example, the request body's comes first
+func exampleSummary(sources []openapi3.Examples, name string) string {
+	for _, examples := range sources {
+		if ex := examples[name]; ex != nil && ex.Value != nil && ex.Value.Summary != "" {
+			return ex.Value.Summary
+		}
+	}
+	return ""
+}
+
+// namedExample picks from named examples: the one of the request being generated, or
+// else the first in alphabetical order, for a body or parameter that doesn't define it
+func (g *Generator) namedExample(examples openapi3.Examples) *openapi3.Example {
+	if ex := examples[g.example]; ex != nil && ex.Value != nil {
+		return ex.Value
+	}
+	for _, name := range slices.Sorted(maps.Keys(examples)) {
+		if ex := examples[name]; ex != nil && ex.Value != nil {
+			return ex.Value
+		}
+	}
+	return nil
+}
+
+// parameterExample returns the explicit example of a parameter, if it has one
+func (g *Generator) parameterExample(param *openapi3.Parameter) (any, bool) {
+	if param.Example != nil {
+		return param.Example, true
+	}
+	if ex := g.namedExample(param.Examples); ex != nil && ex.Value != nil {
+		return ex.Value, true
+	}
+	return nil, false
+}
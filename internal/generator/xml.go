@@ -0,0 +1,179 @@
+package generator
+
+import (
+	"encoding/xml"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// xmlIndent is the indentation of nested XML elements
+const xmlIndent = "  "
+
+// encodeXML serializes a request body as XML, following the xml objects of the schema:
+// elements and attributes are named after their xml name, if any, and get a namespace
+// and prefix. Arrays are only wrapped in an element of their own when wrapped is set.
+// The root element is named after the schema's xml name, its component or else "root".
+func encodeXML(data any, schemaRef *openapi3.SchemaRef) string {
+	// e.g. an example that is already serialized
+	if s, ok := data.(string); ok && strings.HasPrefix(strings.TrimSpace(s), "<") {
+		return s
+	}
+
+	var schema *openapi3.Schema
+	name := "root"
+	if schemaRef != nil {
+		schema = schemaRef.Value
+		if ref := componentName(schemaRef.Ref); ref != "" {
+			name = ref
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+
+	// a document has a single root, so root arrays are always wrapped
+	if items, ok := data.([]any); ok {
+		wrapper := xmlElementName(schema, name)
+		var itemSchema *openapi3.SchemaRef
+		if schema != nil {
+			itemSchema = schema.Items
+		}
+		itemName := "item"
+		if itemSchema != nil && componentName(itemSchema.Ref) != "" {
+			itemName = componentName(itemSchema.Ref)
+		}
+
+		sb.WriteString("<" + wrapper + xmlNamespace(schema) + ">\n")
+		for _, item := range items {
+			writeXMLElement(&sb, itemName, item, schemaValue(itemSchema), 1)
+		}
+		sb.WriteString("</" + wrapper + ">")
+		return sb.String()
+	}
+
+	writeXMLElement(&sb, name, data, schema, 0)
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// writeXMLElement writes value as an element named after the schema's xml name or else
+// name, at the given nesting depth
+func writeXMLElement(sb *strings.Builder, name string, value any, schema *openapi3.Schema, depth int) {
+	indent := strings.Repeat(xmlIndent, depth)
+	element := xmlElementName(schema, name)
+
+	switch v := value.(type) {
+	case map[string]any:
+		var attributes strings.Builder
+		attributes.WriteString(xmlNamespace(schema))
+		var children []string
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			prop := propertySchema(schema, key)
+			if prop != nil && prop.XML != nil && prop.XML.Attribute {
+				attributes.WriteString(" " + xmlElementName(prop, key) + `="` + xmlEscape(scalarString(v[key])) + `"`)
+				continue
+			}
+			children = append(children, key)
+		}
+
+		if len(children) == 0 {
+			sb.WriteString(indent + "<" + element + attributes.String() + "/>\n")
+			return
+		}
+		sb.WriteString(indent + "<" + element + attributes.String() + ">\n")
+		for _, key := range children {
+			writeXMLProperty(sb, key, v[key], propertySchema(schema, key), depth+1)
+		}
+		sb.WriteString(indent + "</" + element + ">\n")
+
+	case []any:
+		// only reached for arrays in arrays, which XML has no natural form for
+		for _, item := range v {
+			writeXMLElement(sb, name, item, nil, depth)
+		}
+
+	case nil:
+		sb.WriteString(indent + "<" + element + xmlNamespace(schema) + "/>\n")
+
+	default:
+		sb.WriteString(indent + "<" + element + xmlNamespace(schema) + ">" + xmlEscape(scalarString(v)) + "</" + element + ">\n")
+	}
+}
+
+// writeXMLProperty writes a property of an object. Array items are elements named after
+// the items' xml name or else the property, wrapped in an element named after the array
+// when it is wrapped.
+func writeXMLProperty(sb *strings.Builder, name string, value any, schema *openapi3.Schema, depth int) {
+	items, ok := value.([]any)
+	if !ok {
+		writeXMLElement(sb, name, value, schema, depth)
+		return
+	}
+
+	var itemSchema *openapi3.Schema
+	if schema != nil {
+		itemSchema = schemaValue(schema.Items)
+	}
+
+	if schema == nil || schema.XML == nil || !schema.XML.Wrapped {
+		for _, item := range items {
+			writeXMLElement(sb, name, item, itemSchema, depth)
+		}
+		return
+	}
+
+	indent := strings.Repeat(xmlIndent, depth)
+	wrapper := xmlElementName(schema, name)
+	if len(items) == 0 {
+		sb.WriteString(indent + "<" + wrapper + xmlNamespace(schema) + "/>\n")
+		return
+	}
+	sb.WriteString(indent + "<" + wrapper + xmlNamespace(schema) + ">\n")
+	for _, item := range items {
+		writeXMLElement(sb, name, item, itemSchema, depth+1)
+	}
+	sb.WriteString(indent + "</" + wrapper + ">\n")
+}
+
+// xmlElementName is the name of the element or attribute for a schema: its xml name or
+// else name, with the xml prefix
+func xmlElementName(schema *openapi3.Schema, name string) string {
+	if schema == nil || schema.XML == nil {
+		return name
+	}
+	if schema.XML.Name != "" {
+		name = schema.XML.Name
+	}
+	if schema.XML.Prefix != "" {
+		name = schema.XML.Prefix + ":" + name
+	}
+	return name
+}
+
+// xmlNamespace declares the namespace of a schema, as an attribute of its element
+func xmlNamespace(schema *openapi3.Schema) string {
+	if schema == nil || schema.XML == nil || schema.XML.Namespace == "" {
+		return ""
+	}
+	if schema.XML.Prefix != "" {
+		return " xmlns:" + schema.XML.Prefix + `="` + xmlEscape(schema.XML.Namespace) + `"`
+	}
+	return ` xmlns="` + xmlEscape(schema.XML.Namespace) + `"`
+}
+
+// xmlEscape escapes text for use in XML content and attribute values
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// schemaValue returns the schema a reference resolves to, nil if there is none
+func schemaValue(ref *openapi3.SchemaRef) *openapi3.Schema {
+	if ref == nil {
+		return nil
+	}
+	return ref.Value
+}
@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestEncodeXML(t *testing.T) {
+	str := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}}
+	book := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Book",
+		Value: &openapi3.Schema{
+			Type: &openapi3.Types{"object"},
+			XML:  &openapi3.XML{Name: "book", Namespace: "https://example.com/schema/book", Prefix: "smp"},
+			Properties: openapi3.Schemas{
+				"id": &openapi3.SchemaRef{Value: &openapi3.Schema{
+					Type: &openapi3.Types{"integer"},
+					XML:  &openapi3.XML{Attribute: true},
+				}},
+				"title": &openapi3.SchemaRef{Value: &openapi3.Schema{
+					Type: &openapi3.Types{"string"},
+					XML:  &openapi3.XML{Name: "name"},
+				}},
+				"authors": &openapi3.SchemaRef{Value: &openapi3.Schema{
+					Type:  &openapi3.Types{"array"},
+					Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, XML: &openapi3.XML{Name: "author"}}},
+					XML:   &openapi3.XML{Wrapped: true},
+				}},
+				"tags": &openapi3.SchemaRef{Value: &openapi3.Schema{
+					Type:  &openapi3.Types{"array"},
+					Items: str,
+				}},
+			},
+		},
+	}
+
+	data := map[string]any{
+		"id":      1,
+		"title":   "Fish & Chips",
+		"authors": []any{"Jane", "John"},
+		"tags":    []any{"food", "uk"},
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<smp:book xmlns:smp="https://example.com/schema/book" id="1">
+  <authors>
+    <author>Jane</author>
+    <author>John</author>
+  </authors>
+  <tags>food</tags>
+  <tags>uk</tags>
+  <name>Fish &amp; Chips</name>
+</smp:book>`
+
+	if result := encodeXML(data, book); result != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, result)
+	}
+}
+
+func TestEncodeXML_Root(t *testing.T) {
+	user := &openapi3.SchemaRef{
+		Ref:   "#/components/schemas/User",
+		Value: &openapi3.Schema{Type: &openapi3.Types{"object"}},
+	}
+
+	tests := []struct {
+		name     string
+		data     any
+		schema   *openapi3.SchemaRef
+		expected string
+	}{
+		{
+			name:     "component name",
+			data:     map[string]any{"name": "jane"},
+			schema:   user,
+			expected: "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<User>\n  <name>jane</name>\n</User>",
+		},
+		{
+			name:     "inline schema",
+			data:     map[string]any{},
+			schema:   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"object"}}},
+			expected: "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<root/>",
+		},
+		{
+			name:     "array",
+			data:     []any{map[string]any{"name": "jane"}},
+			schema:   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"array"}, Items: user}},
+			expected: "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<root>\n  <User>\n    <name>jane</name>\n  </User>\n</root>",
+		},
+		{
+			name:     "serialized example",
+			data:     "<User><name>jane</name></User>",
+			schema:   user,
+			expected: "<User><name>jane</name></User>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := encodeXML(tt.data, tt.schema); result != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
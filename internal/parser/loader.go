@@ -3,12 +3,14 @@ import (
 	"context" 
 	"fmt"
 	"net/url"
+	"slices"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
-// Loads an open api spec based either on a filepath or URL
-func LoadSpec(path string) (*openapi3.T, error) {
+// Loads an open api spec based either on a filepath or URL, opts are passed on to the
+// validation of the spec
+func LoadSpec(path string, opts ...openapi3.ValidationOption) (*openapi3.T, error) {
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
 
@@ -26,10 +28,116 @@ func LoadSpec(path string) (*openapi3.T, error) {
 		return nil, fmt.Errorf("failed to load spec: %w", err)
 	}
 
-	// validate
-	if err := doc.Validate(context.Background()); err != nil {
+	// validate, leaving out the examples given by externalValue: their value isn't loaded,
+	// so it would be rejected as null. All other examples are still validated.
+	restore := withoutExternalValues(doc)
+	err = doc.Validate(context.Background(), opts...)
+	restore()
+	if err != nil {
 		return nil, fmt.Errorf("spec validation failed: %w", err)
 	}
 
 	return doc, nil
 }
+
+// withoutExternalValues removes the examples given by externalValue from the spec, and
+// returns a function that puts them back
+func withoutExternalValues(doc *openapi3.T) func() {
+	type removed struct {
+		examples openapi3.Examples
+		name     string
+		example  *openapi3.ExampleRef
+	}
+
+	var external []removed
+	for _, examples := range exampleMaps(doc) {
+		for name, ex := range examples {
+			if ex != nil && ex.Value != nil && ex.Value.Value == nil && ex.Value.ExternalValue != "" {
+				external = append(external, removed{examples, name, ex})
+				delete(examples, name)
+			}
+		}
+	}
+
+	return func() {
+		for _, r := range external {
+			r.examples[r.name] = r.example
+		}
+	}
+}
+
+// exampleMaps collects the named examples of the spec: those of the components and of
+// all parameters, headers, request bodies and responses
+func exampleMaps(doc *openapi3.T) []openapi3.Examples {
+	var examples []openapi3.Examples
+	var parameters []*openapi3.Parameter
+	var contents []openapi3.Content
+
+	addResponses := func(responses map[string]*openapi3.ResponseRef) {
+		for _, response := range responses {
+			if response.Value == nil {
+				continue
+			}
+			contents = append(contents, response.Value.Content)
+			for _, header := range response.Value.Headers {
+				if header.Value != nil {
+					parameters = append(parameters, &header.Value.Parameter)
+				}
+			}
+		}
+	}
+
+	if doc.Components != nil {
+		examples = append(examples, doc.Components.Examples)
+		for _, param := range doc.Components.Parameters {
+			if param.Value != nil {
+				parameters = append(parameters, param.Value)
+			}
+		}
+		for _, header := range doc.Components.Headers {
+			if header.Value != nil {
+				parameters = append(parameters, &header.Value.Parameter)
+			}
+		}
+		for _, rb := range doc.Components.RequestBodies {
+			if rb.Value != nil {
+				contents = append(contents, rb.Value.Content)
+			}
+		}
+		addResponses(doc.Components.Responses)
+	}
+
+	if doc.Paths != nil {
+		for _, pathItem := range doc.Paths.Map() {
+			params := slices.Clone(pathItem.Parameters)
+			for _, op := range pathItem.Operations() {
+				params = append(params, op.Parameters...)
+				if op.RequestBody != nil && op.RequestBody.Value != nil {
+					contents = append(contents, op.RequestBody.Value.Content)
+				}
+				if op.Responses != nil {
+					addResponses(op.Responses.Map())
+				}
+			}
+			for _, param := range params {
+				if param.Value != nil {
+					parameters = append(parameters, param.Value)
+				}
+			}
+		}
+	}
+
+	for _, param := range parameters {
+		examples = append(examples, param.Examples)
+		contents = append(contents, param.Content)
+	}
+	for _, content := range contents {
+		for _, mt := range content {
+			if mt != nil {
+				examples = append(examples, mt.Examples)
+			}
+		}
+	}
+
+	return examples
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// externalValueSpec is a spec with an example given by externalValue, and an inline
+// example for a name property of the given type
+func externalValueSpec(t *testing.T, nameType string) string {
+	spec := `openapi: 3.0.3
+info:
+  title: Petstore
+  version: "1.0"
+paths:
+  /pet:
+    post:
+      operationId: addPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: ` + nameType + `
+            examples:
+              large:
+                externalValue: examples/pet.json
+              small:
+                value:
+                  name: doggie
+      responses:
+        "200":
+          description: ok
+`
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(path, []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadSpec_ExternalValue(t *testing.T) {
+	doc, err := LoadSpec(externalValueSpec(t, "string"))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+
+	// the external example is put back after validation
+	examples := doc.Paths.Find("/pet").Post.RequestBody.Value.Content["application/json"].Examples
+	if ex := examples["large"]; ex == nil || ex.Value.ExternalValue != "examples/pet.json" {
+		t.Errorf("expected the external example to be kept, got: %v", examples)
+	}
+}
+
+func TestLoadSpec_ExternalValueWithInvalidExample(t *testing.T) {
+	// the inline example's name is a string, not an integer
+	_, err := LoadSpec(externalValueSpec(t, "integer"))
+	if err == nil || !strings.Contains(err.Error(), "example small") {
+		t.Errorf("expected the invalid inline example to fail validation, got: %v", err)
+	}
+}
+
+func TestLoadSpec_ExternalValueComponent(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: Petstore
+  version: "1.0"
+paths:
+  /pet:
+    post:
+      operationId: addPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+            examples:
+              large:
+                $ref: "#/components/examples/LargePet"
+      responses:
+        "200":
+          description: ok
+components:
+  examples:
+    LargePet:
+      externalValue: examples/pet.json
+`
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(path, []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := LoadSpec(path)
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	if ex := doc.Components.Examples["LargePet"]; ex == nil || ex.Value.ExternalValue != "examples/pet.json" {
+		t.Errorf("expected the external example to be kept, got: %v", doc.Components.Examples)
+	}
+}
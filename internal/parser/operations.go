@@ -112,7 +112,16 @@ func hasTag(op *openapi3.Operation, tag string) bool {
 func FindOperations(spec *openapi3.T, operationID, path, tag string) []Operation {
 	var results []Operation
 
-	for p, pathItem := range spec.Paths.Map() {
+	// sorted, so operations are always generated in the same order
+	var paths []string
+	for p := range spec.Paths.Map() {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		pathItem := spec.Paths.Map()[p]
+
 		// filter by path if specified
 		if path != "" && p != path {
 			continue
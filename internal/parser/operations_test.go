@@ -2,6 +2,7 @@ package parser
 
 import (
 	"slices"
+	"strings"
 	"testing"
 )
 
@@ -238,3 +239,17 @@ func TestFindOperations_WithPathParameters(t *testing.T) {
 		t.Error("expected operation to have parameters")
 	}
 }
+
+func TestFindOperations_SortedByPath(t *testing.T) {
+	spec, err := LoadSpec("../../test/petstore.yml")
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+
+	ops := FindOperations(spec, "", "", "")
+
+	// operations must come out in the same order every time
+	if !slices.IsSortedFunc(ops, func(a, b Operation) int { return strings.Compare(a.Path, b.Path) }) {
+		t.Error("expected operations to be sorted by path")
+	}
+}